@@ -1,13 +1,25 @@
+from .bench import bench_command
+from .check import check_command
 from .drop import drop_command
+from .export import export_command
 from .index import index_command
 from .init import init_command
 from .mcp import mcp_command
+from .schema import schema_command
 from .search import search_command
+from .settings import config_app
+from .workspace import workspace_app
 
 __all__ = [
+    "bench_command",
+    "check_command",
+    "config_app",
     "drop_command",
+    "export_command",
     "search_command",
     "mcp_command",
     "index_command",
     "init_command",
+    "schema_command",
+    "workspace_app",
 ]
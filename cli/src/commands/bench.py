@@ -0,0 +1,82 @@
+import math
+import statistics
+from pathlib import Path
+from typing import Literal
+
+from core import get_collection_name
+
+BenchOutput = Literal["simple", "json"]
+
+
+def latency_stats(samples: list[float]) -> dict[str, float]:
+    ordered = sorted(samples)
+    return {
+        "min_ms": round(ordered[0], 3),
+        "median_ms": round(statistics.median(ordered), 3),
+        "p95_ms": round(ordered[math.ceil(0.95 * len(ordered)) - 1], 3),
+        "max_ms": round(ordered[-1], 3),
+    }
+
+
+async def bench_command(
+    path: Path,
+    query: str,
+    iterations: int = 10,
+    limit: int = 5,
+    output: BenchOutput = "simple",
+) -> None:
+    """Measure steady-state search latency.
+
+    Runs the same search repeatedly with one search service, discarding the
+    first run as warm-up.
+
+    Common invocations:
+
+        code-context bench . "jwt middleware" --iterations 50
+        code-context bench ~/projects/app "retry logic" --output json
+
+    Args:
+        path: Path to the indexed codebase
+        query: Search query text
+        iterations: Number of measured searches, excluding the warm-up run
+        limit: Maximum number of results per search (1-50)
+        output: Output format: simple or json
+    """
+    import time
+
+    from rich import print, print_json
+
+    from config import load_config
+    from service_factory import ServiceFactory
+
+    if iterations < 1:
+        print("Iterations must be at least 1")
+        return
+
+    path = path.expanduser().absolute()
+    settings, has_changed = load_config(get_collection_name(path))
+
+    if has_changed:
+        print("Please first run index command with --force option")
+        return
+
+    search_service = ServiceFactory(settings).get_search_service()
+
+    samples = []
+    for run in range(iterations + 1):
+        started = time.perf_counter()
+        await search_service.search(path, query, top_k=limit, threshold=0.0)
+        if run > 0:
+            samples.append((time.perf_counter() - started) * 1000)
+
+    stats = latency_stats(samples)
+
+    if output == "json":
+        import json
+
+        print_json(json.dumps({"query": query, "iterations": iterations, **stats}))
+        return
+
+    print(f"Iterations: {iterations}")
+    for name, value in stats.items():
+        print(f"{name.removesuffix('_ms').capitalize()}: {value} ms")
@@ -0,0 +1,69 @@
+from pathlib import Path
+
+from core import get_collection_name
+
+
+async def check_command(
+    path: Path = Path("."),
+    auto_reindex: bool = False,
+    threshold: float = 0.1,
+    fail_on_stale: bool = False,
+) -> None:
+    """Check whether the index is stale by comparing file counts.
+
+    Common invocations:
+
+        code-context check
+        code-context check ~/projects/app --threshold 0.05 --auto-reindex
+        code-context check . --fail-on-stale
+
+    Args:
+        path: Path to the indexed codebase (defaults to current directory)
+        auto_reindex: Reindex automatically when drift exceeds the threshold
+        threshold: Allowed relative drift between local and indexed file counts
+        fail_on_stale: Exit non-zero if any file changed since the last index
+    """
+    from rich import print
+
+    from config import load_config
+    from service_factory import ServiceFactory
+
+    from .index import index_command
+
+    collection_name = get_collection_name(path.expanduser().absolute())
+
+    settings, _ = load_config(collection_name)
+    services = ServiceFactory(settings)
+
+    indexed_files = await services.get_indexing_service().get_indexed_files(path)
+    if indexed_files is None:
+        print(f"Path {path} is not indexed. Run index command first.")
+        return
+
+    local_count = len(await services.get_synchronizer().list_files(path))
+    indexed_count = len(indexed_files)
+    drift = abs(local_count - indexed_count) / max(local_count, 1)
+
+    print(f"Local files: {local_count}")
+    print(f"Indexed files: {indexed_count}")
+    print(f"Drift: {drift:.1%}")
+
+    if fail_on_stale:
+        changes = await services.get_synchronizer().peek_changes(path)
+        print(
+            f"Changed since last index: {len(changes.added)} added, "
+            f"{len(changes.modified)} modified, {len(changes.removed)} removed"
+        )
+        if changes.num_changes or drift > threshold:
+            print("[red]Index is stale, run index command[/red]")
+            raise SystemExit(1)
+        print("[green]Index is up to date[/green]")
+        return
+
+    if drift <= threshold:
+        print("[green]Index is up to date[/green]")
+        return
+
+    print("[yellow]Index may be stale, reindex is recommended[/yellow]")
+    if auto_reindex:
+        await index_command(path)
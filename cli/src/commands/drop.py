@@ -7,22 +7,45 @@ from config import delete_config
 
 async def drop_command(
     path: Path = Path("."),
+    ignore_missing: bool = False,
+    force: bool = False,
 ) -> None:
     """Remove a codebase from the index.
 
+    Common invocations:
+
+        code-context drop
+        code-context drop ~/projects/app
+        code-context drop ~/projects/app --ignore-missing
+
     Args:
         path: Path to the codebase to remove from index (defaults to current directory)
+        ignore_missing: Exit successfully when the path is not indexed in Qdrant
+        force: Skip checking that the path is indexed in Qdrant before dropping it
     """
+    from rich import print
+
     from config import load_config
     from service_factory import ServiceFactory
 
-    collection_name = get_collection_name(path.expanduser().absolute())
+    collection_name = get_collection_name(path.expanduser().absolute().resolve())
 
     settings, _ = load_config()
     services = ServiceFactory(settings)
 
     indexing_service = services.get_indexing_service()
 
+    is_missing = not force and not await indexing_service.is_indexed(path)
+
     await indexing_service.delete(path)
 
     delete_config(collection_name)
+
+    if is_missing:
+        if ignore_missing:
+            return
+        print(
+            f"[yellow]Path {path} is not currently indexed, "
+            "removed its local snapshot and config[/yellow]"
+        )
+        raise SystemExit(1)
@@ -0,0 +1,75 @@
+from pathlib import Path
+
+from core import get_collection_name
+
+
+async def export_command(
+    path: Path = Path("."),
+    query: str | None = None,
+    out: Path = Path("export.jsonl"),
+    threshold: float = 0.0,
+    max_results: int | None = None,
+) -> None:
+    """Export all indexed chunks of a codebase, or all matches of a query, as JSONL.
+
+    Common invocations:
+
+        code-context export
+        code-context export ~/projects/app --out app.jsonl
+        code-context export . --query "error handling" --max-results 2000
+
+    Args:
+        path: Path to the indexed codebase (defaults to current directory)
+        query: Export the matches of this query instead of every chunk
+        out: JSONL file to write, one result per line
+        threshold: Minimum similarity for query matches (0.0-1.0)
+        max_results: Stop after this many results (--query exports 1000 by default)
+    """
+    import json
+    from dataclasses import asdict
+
+    from rich import print
+    from rich.console import Console
+
+    from config import load_config
+    from service_factory import ServiceFactory
+
+    if max_results is not None and max_results < 1:
+        print("Max results must be at least 1")
+        return
+
+    if not 0.0 <= threshold <= 1.0:
+        print("Threshold must be between 0.0 and 1.0")
+        return
+
+    collection_name = get_collection_name(path.expanduser().absolute())
+
+    settings, has_changed = load_config(collection_name)
+    if has_changed:
+        print("Please first run index command with --force option")
+        return
+
+    services = ServiceFactory(settings)
+    if not await services.get_indexing_service().is_indexed(path):
+        print(f"Path {path} is not indexed. Run index command first.")
+        return
+
+    search_service = services.get_search_service()
+    exported = 0
+    with (
+        out.open("w", encoding="utf-8") as file,
+        Console(stderr=True).status("Exporting") as status,
+    ):
+        async for page in search_service.export(path, query, threshold, max_results):
+            for result in page:
+                file.write(json.dumps(asdict(result)) + "\n")
+            exported += len(page)
+            status.update(f"Exported {exported} results")
+
+    print(f"Exported {exported} results to {out}")
+    limit = max_results or search_service.EXPORT_QUERY_LIMIT
+    if query and exported == limit:
+        Console(stderr=True).print(
+            f"[yellow]Export stopped at {limit} matches, more may exist. "
+            "Raise --max-results to export more.[/yellow]"
+        )
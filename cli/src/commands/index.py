@@ -1,6 +1,14 @@
+from datetime import datetime
 from pathlib import Path
+from typing import Literal
 
-from core import get_collection_name
+from core import (
+    FileSynchronizer,
+    IndexingService,
+    IndexingStats,
+    get_collection_name,
+    write_text_atomic,
+)
 
 from config import save_config
 
@@ -8,32 +16,184 @@ from config import save_config
 async def index_command(
     path: Path = Path("."),
     force: bool = False,
+    deadline: datetime | None = None,
+    ignore_file: str | None = None,
+    every: str | None = None,
+    report: Path | None = None,
+    print_plan: bool = False,
+    output: Literal["simple", "json"] = "simple",
+    if_stale: bool = False,
 ) -> None:
     """Index a codebase for semantic search.
 
+    Common invocations:
+
+        code-context index
+        code-context index ~/projects/app --force
+        code-context index . --deadline 2026-01-01T18:00:00Z
+        code-context index /srv/repo --every 10m
+        code-context index . --report manifest.json
+        code-context index . --print-plan
+        code-context index . --if-stale
+
     Args:
         path: Path to the codebase to index (defaults to current directory)
         force: Force complete reindexing instead of incremental updates
+        deadline: Absolute RFC3339 time after which indexing is aborted
+        ignore_file: Ignore file merged over .gitignore (default .code-context-ignore)
+        every: Keep running and reindex on this interval (e.g. 10m) until interrupted
+        report: Write a JSON manifest of indexed files and hashes to this path
+        print_plan: List the files that would be indexed with their total size and exit
+        output: Result format: simple (summary and per-extension table) or json
+        if_stale: Only index when files changed or counts drifted since the last index
     """
+    import asyncio
+
     from rich import print
 
     from config import load_config
+    from jobs import indexing_job
+    from runtime import install_debug_toggle, parse_duration, run_until
     from service_factory import ServiceFactory
 
     collection_name = get_collection_name(path.expanduser().absolute())
 
     settings, has_changed = load_config(collection_name)
 
-    if has_changed and not force:
+    try:
+        interval = parse_duration(every) if every else None
+    except ValueError as exc:
+        print(str(exc))
+        return
+
+    if if_stale and interval is not None:
+        print("--if-stale cannot be combined with --every")
+        return
+
+    if has_changed and not force and not print_plan:
         print(
             "Config has changed since last load. Please rerun command with --force option"
         )
         return
 
-    services = ServiceFactory(settings)
+    service_settings = settings
+    if ignore_file is not None:
+        service_settings = settings.model_copy(deep=True)
+        service_settings.sync.ignore_file = ignore_file
+
+    services = ServiceFactory(service_settings)
+
+    if print_plan:
+        files = await services.get_synchronizer().list_files(path)
+        for relative_path in sorted(files):
+            print(relative_path)
+        total_size = sum(size for size, _, _ in files.values())
+        print(f"{len(files)} files, {format_size(total_size)}")
+        return
 
     indexing_service = services.get_indexing_service()
 
-    await indexing_service.index(path, force)
+    if if_stale and not force and not await is_stale(
+        path, indexing_service, services.get_synchronizer()
+    ):
+        print("Index already fresh")
+        return
+
+    async with run_until(deadline):
+        if interval is None:
+            with indexing_job(collection_name):
+                stats = await indexing_service.index(path, force)
+            save_config(settings, collection_name)
+            print_stats(stats, output == "json")
+            if report is not None:
+                write_manifest(report, path, stats, services.get_synchronizer())
+                print(f"Manifest written to {report}")
+            return
+
+        install_debug_toggle()
+        while True:
+            try:
+                with indexing_job(collection_name):
+                    stats = await indexing_service.index(path, force)
+            except Exception as exc:
+                print(f"[red]{datetime.now():%Y-%m-%d %H:%M:%S} failed:[/red] {exc}")
+            else:
+                save_config(settings, collection_name)
+                print(f"{datetime.now():%Y-%m-%d %H:%M:%S} {format_stats(stats)}")
+                if report is not None:
+                    write_manifest(report, path, stats, services.get_synchronizer())
+                force = False
+            await asyncio.sleep(interval.total_seconds())
+
+
+async def is_stale(
+    path: Path, indexing_service: IndexingService, synchronizer: FileSynchronizer
+) -> bool:
+    indexed_files = await indexing_service.get_indexed_files(path)
+    if indexed_files is None:
+        return True
+    if len(await synchronizer.list_files(path)) != len(indexed_files):
+        return True
+    return (await synchronizer.peek_changes(path)).num_changes > 0
+
+
+def format_size(size: int) -> str:
+    value = float(size)
+    for unit in ("B", "KB", "MB"):
+        if value < 1024:
+            return f"{value:.0f} {unit}" if unit == "B" else f"{value:.1f} {unit}"
+        value /= 1024
+    return f"{value:.1f} GB"
+
+
+def print_stats(stats: IndexingStats, as_json: bool = False) -> None:
+    import json
+    from dataclasses import asdict
+
+    from rich import print, print_json
+    from rich.table import Table
+
+    if as_json:
+        print_json(json.dumps(asdict(stats)))
+        return
+
+    print(format_stats(stats))
+    if not stats.by_extension:
+        return
+
+    table = Table("Extension", "Files", "Chunks")
+    for extension, counts in sorted(
+        stats.by_extension.items(), key=lambda item: (-item[1].chunks, item[0])
+    ):
+        table.add_row(extension, str(counts.files), str(counts.chunks))
+    print(table)
+
+
+def format_stats(stats: IndexingStats) -> str:
+    if stats.added + stats.modified + stats.removed == 0:
+        return "Index is up to date"
+    return (
+        f"Indexed {stats.added} new and {stats.modified} modified files "
+        f"({stats.chunks} chunks), pruned {stats.removed} deleted files"
+    )
+
+
+def write_manifest(
+    report: Path, path: Path, stats: IndexingStats, synchronizer: FileSynchronizer
+) -> None:
+    import json
+    from dataclasses import asdict
 
-    save_config(settings, collection_name)
+    codebase_path = path.expanduser().resolve()
+    records = synchronizer.state_repository.load(codebase_path)
+    manifest = {
+        "path": str(codebase_path),
+        "collection": get_collection_name(codebase_path),
+        "generated_at": datetime.now().astimezone().isoformat(),
+        "stats": asdict(stats),
+        "files": [
+            {"path": relative_path, "size": record.size, "hash": record.hash}
+            for relative_path, record in sorted(records.items())
+        ],
+    }
+    write_text_atomic(report, json.dumps(manifest, indent=2))
@@ -1,13 +1,39 @@
-from config import load_config
+from typing import Literal
 
+from pydantic import HttpUrl
 
-async def init_command() -> None:
+from config import load_config, normalize_url
 
-    from pydantic import HttpUrl
+
+def ask_url(prompt: str, default: HttpUrl) -> HttpUrl:
+    from pydantic import ValidationError
+    from rich import print
+    from rich.prompt import Prompt
+
+    while True:
+        value = Prompt.ask(prompt, default=str(default))
+        try:
+            return normalize_url(value)
+        except ValidationError:
+            print(f"[red]Invalid URL: {value}[/red]")
+
+
+async def init_command(discover: Literal["docker"] | None = None) -> None:
+    """Interactively create the CLI configuration.
+
+    Common invocations:
+
+        code-context init
+        code-context init --discover docker
+
+    Args:
+        discover: Prefill service URLs from running containers (docker)
+    """
     from rich import print, print_json
     from rich.prompt import Confirm, IntPrompt, Prompt
 
     from config import DEFAULT_CONFIG_PATH, AppSettings, save_config
+    from discovery import DiscoveredServices, discover_docker
 
     config = AppSettings()
 
@@ -18,22 +44,42 @@ async def init_command() -> None:
 
         config, _ = load_config()
 
+    discovered = DiscoveredServices()
+    if discover == "docker":
+        discovered = discover_docker()
+        if discovered.qdrant_url is None and discovered.ollama_url is None:
+            print("[yellow]No containers discovered, using defaults[/yellow]")
+        else:
+            config.qdrant.url = discovered.qdrant_url or config.qdrant.url
+            print(f"Discovered Qdrant: {discovered.qdrant_url or 'not found'}")
+            print(f"Discovered Ollama: {discovered.ollama_url or 'not found'}")
+
     print("\n[bold]Qdrant Vector Database[/bold]")
-    config.qdrant.url = HttpUrl(
-        Prompt.ask("Qdrant host", default=str(config.qdrant.url))
-    )
+    config.qdrant.url = ask_url("Qdrant host", config.qdrant.url)
 
     if config.qdrant.url.host != "localhost":
         config.qdrant.api_key = Prompt.ask(
             "Qdrant API key", default=config.qdrant.api_key, password=True
         )
 
+    if config.qdrant.api_key:
+        config.qdrant.auth_header = Prompt.ask(
+            "Qdrant auth header", default=config.qdrant.auth_header or "Authorization"
+        )
+        auth_scheme = Prompt.ask(
+            "Qdrant auth scheme (none to send the bare key)",
+            default=config.qdrant.auth_scheme or "none",
+        )
+        config.qdrant.auth_scheme = "" if auth_scheme == "none" else auth_scheme
+
     print("\n[bold]Embedding Service[/bold]")
 
     use_ollama = Confirm.ask("Use Ollama for embeddings?", default=True)
 
     if use_ollama:
-        config.code_embedding.url = HttpUrl("http://localhost:11434/v1")
+        config.code_embedding.url = discovered.ollama_url or HttpUrl(
+            "http://localhost:11434/v1"
+        )
         config.code_embedding.api_key = "ollama"
         config.code_embedding.model = Prompt.ask(
             "Ollama embedding model", default=config.code_embedding.model
@@ -42,8 +88,8 @@ async def init_command() -> None:
             "Embedding size", default=config.code_embedding.size
         )
     else:
-        config.code_embedding.url = HttpUrl(
-            Prompt.ask("Embedding service URL", default=str(config.code_embedding.url))
+        config.code_embedding.url = ask_url(
+            "Embedding service URL", config.code_embedding.url
         )
         config.code_embedding.api_key = Prompt.ask(
             "Embedding service API key",
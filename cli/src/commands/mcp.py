@@ -11,6 +11,7 @@ def mcp_command() -> None:
     from pydantic import PositiveInt
 
     from config import load_config
+    from runtime import install_debug_toggle
     from service_factory import ServiceFactory
 
     mcp = FastMCP("code-context-search")
@@ -45,4 +46,5 @@ def mcp_command() -> None:
 
         return results
 
+    install_debug_toggle()
     mcp.run(transport="stdio")
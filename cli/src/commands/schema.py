@@ -0,0 +1,73 @@
+from pathlib import Path
+from typing import Any, Literal
+
+from core import IndexingStats, SearchResult, write_text_atomic
+
+SchemaTarget = Literal["search", "index"]
+
+JSON_SCHEMA_DIALECT = "https://json-schema.org/draft/2020-12/schema"
+
+_CONTEXT_LINES = {"type": "array", "items": {"type": "string"}}
+OPTIONAL_RESULT_PROPERTIES: dict[str, dict[str, Any]] = {
+    "content_hash": {
+        "type": "string",
+        "description": "Present with --include-content-hash",
+    },
+    "blame": {"type": "string", "description": "Present with --blame"},
+    "matches": {"type": "integer", "description": "Present with --match-count"},
+    "local_check": {"type": "string", "description": "Present with --verify-local"},
+    "context_before": {**_CONTEXT_LINES, "description": "Present with --before"},
+    "context_after": {**_CONTEXT_LINES, "description": "Present with --after"},
+}
+
+
+def search_schema() -> dict[str, Any]:
+    from pydantic import TypeAdapter
+
+    result_schema = TypeAdapter(SearchResult).json_schema()
+    result_schema["properties"].update(OPTIONAL_RESULT_PROPERTIES)
+    return {
+        "$schema": JSON_SCHEMA_DIALECT,
+        "title": "SearchResponse",
+        "type": "object",
+        "properties": {
+            "results": {"type": "array", "items": result_schema},
+            "total": {"type": "integer"},
+        },
+        "required": ["results", "total"],
+    }
+
+
+def index_schema() -> dict[str, Any]:
+    from pydantic import TypeAdapter
+
+    return {
+        "$schema": JSON_SCHEMA_DIALECT,
+        **TypeAdapter(IndexingStats).json_schema(),
+    }
+
+
+def schema_command(target: SchemaTarget = "search", output: Path | None = None) -> None:
+    """Print the JSON Schema of a command's json output.
+
+    Common invocations:
+
+        code-context schema search
+        code-context schema index --output index.schema.json
+
+    Args:
+        target: Command whose json output schema is printed
+        output: Write the schema to this file instead of printing it
+    """
+    import json
+
+    from rich import print, print_json
+
+    schemas = {"search": search_schema, "index": index_schema}
+    schema = json.dumps(schemas[target](), indent=2)
+    if output is None:
+        print_json(schema)
+        return
+
+    write_text_atomic(output, schema + "\n")
+    print(f"Schema written to {output}")
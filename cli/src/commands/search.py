@@ -1,54 +1,19 @@
+from datetime import datetime
 from pathlib import Path
-from typing import Literal
+from typing import Any
 
 from core import SearchResult, get_collection_name
 
-OutputType = Literal["simple", "json", "simple-json"]
-
-
-def json_format(results: list[SearchResult]) -> str:
-    import json
-    from dataclasses import asdict
-
-    formatted_results = []
-
-    for result in results:
-        formatted_result = asdict(result)
-        formatted_results.append(formatted_result)
-
-    return json.dumps(
-        {"results": formatted_results, "total": len(formatted_results)}, indent=2
-    )
-
-
-def json_format_simple(results: list[SearchResult]) -> str:
-    import json
-
-    formatted_results = []
-
-    for result in results:
-        formatted_result = {"content": result.content.strip()}
-        formatted_results.append(formatted_result)
-
-    return json.dumps(formatted_results)
-
-
-def print_results(results: list[SearchResult], output_type: OutputType) -> None:
-    from rich import print, print_json
-    from rich.syntax import Syntax
-
-    if output_type == "json":
-        print_json(json_format(results))
-    if output_type == "simple-json":
-        print_json(json_format_simple(results))
-    else:
-        for result in results:
-            print(f"Path: {result.relative_path}")
-            print(f"Start line: {result.start_line}")
-            print(f"End line: {result.end_line}")
-            if result.doc is not None:
-                print(f"Explanation: {result.doc}")
-            print(Syntax(result.content.strip(), result.language, line_numbers=False))
+from config import AppSettings
+from results import (
+    DisplayOptions,
+    FanOutSearch,
+    FilterOptions,
+    HyperlinkScheme,
+    OutputType,
+    SearchDiagnostics,
+    SearchTarget,
+)
 
 
 async def search_command(
@@ -57,38 +22,366 @@ async def search_command(
     limit: int = 5,
     output: OutputType = "simple",
     threshold: float = 0.0,
+    include_content_hash: bool = False,
+    blame: bool = False,
+    verbose: bool = False,
+    deadline: datetime | None = None,
+    word: bool = False,
+    case_sensitive: bool = False,
+    summary_only: bool = False,
+    depth: int = 1,
+    min_depth: int | None = None,
+    max_depth: int | None = None,
+    since_index: str | None = None,
+    min_results: int | None = None,
+    timing_json: bool = False,
+    pins_file: Path | None = None,
+    before: int = 0,
+    after: int = 0,
+    verify_local: bool = False,
+    drop_stale: bool = False,
+    normalize_eol: bool = False,
+    max_content_lines: int | None = None,
+    output_dir: Path | None = None,
+    count_by_language: bool = False,
+    path_as_is: bool = False,
+    explain_query: bool = False,
+    around_symbol: bool = False,
+    compare_with: Path | None = None,
+    limit_per_file: int | None = None,
+    not_: list[str] | None = None,
+    score_precision: int | None = None,
+    strip_prefix: str | None = None,
+    auto_strip: bool = False,
+    redact: bool = False,
+    redact_file: Path | None = None,
+    retry_empty: bool = False,
+    boost_path: list[str] | None = None,
+    match_count: bool = False,
+    hyperlinks: bool | None = None,
+    hyperlink_scheme: HyperlinkScheme = "file",
+    must_include: list[str] | None = None,
+    from_results: Path | None = None,
+    interactive_filter: bool = False,
+    wait_for_index: bool = False,
+    skip_index_check: bool = False,
+    normalize_query: bool = False,
+    lowercase_query: bool = False,
+    filter_trace: bool = False,
 ) -> None:
     """Search indexed code semantically.
 
+    Common invocations:
+
+        code-context search "jwt middleware"
+        code-context search "database connection setup" ~/projects/app --limit 10
+        code-context search "retry logic" --output json --include-content-hash
+        code-context search "user lookup" --word --max-depth 2 --blame
+        code-context search "token refresh -mock" --not test
+        code-context search "session store" --boost-path 'src/**=1.5'
+        code-context search "checkout flow" @shop
+
     Args:
         query: Search query text
-        path: Path to search in (defaults to current directory)
+        path: Path to search in (defaults to current directory), or @workspace
         limit: Maximum number of results to return (1-50)
-        output: Output format: simple (default), json (full details), simple-json (content only)
-        max_graph_hops: Optional number of graph hops to expand results (requires graph feature)
+        output: Output format: simple, json, simple-json, quickfix, ctags or sarif
+        include_content_hash: Add a sha256 content hash to each json result for caching
+        blame: Annotate each result with the author and commit of its latest change
+        verbose: Print the resolved search parameters to stderr before searching
+        deadline: Absolute RFC3339 time after which the search is aborted
+        word: Keep only results containing every query term as a whole word
+        case_sensitive: Match --word and --not terms case-sensitively
+        summary_only: Print per-directory counts and best scores instead of snippets
+        depth: Directory depth used to group results with --summary-only
+        min_depth: Drop results nested fewer directories deep than this
+        max_depth: Drop results nested more directories deep than this
+        since_index: Keep only chunks indexed within this window (e.g. 30m, 2h, 1d)
+        min_results: Re-query until this many results pass filters (1 to --limit)
+        timing_json: Emit per-phase durations as JSON to stderr after the search
+        pins_file: JSON pin/exclude globs, defaults to <path>/.code-context-pins.json
+        before: Lines of local file context to show before each match
+        after: Lines of local file context to show after each match
+        verify_local: Flag results whose content no longer matches the local file
+        drop_stale: Drop results whose content no longer matches the local file
+        normalize_eol: Convert CRLF and CR line endings in result content to LF
+        max_content_lines: Trim snippets to this many lines at statement boundaries
+        output_dir: Write each matched file's results to its own file in this directory
+        count_by_language: Print result counts and shares per language instead
+        path_as_is: Use the path verbatim, for indexes built on another host
+        explain_query: Print how the query and flags map to the request, then exit
+        around_symbol: Expand each result to its enclosing declaration in the local file
+        compare_with: Show added, removed and moved results against a saved json output
+        limit_per_file: Keep at most this many results per file, fetching more to fill
+        not_: Drop results whose content or path contains this term (repeatable)
+        score_precision: Round scores in json, sarif and summary output to N decimals
+        strip_prefix: Replace this leading directory in displayed paths with …/
+        auto_strip: Strip the longest directory shared by all displayed paths
+        redact: Mask common secrets (keys, tokens, emails) in every output format
+        redact_file: Extra redaction regexes, one per line (implies --redact)
+        retry_empty: Workaround for a still-warming index, retry empty searches twice
+        boost_path: Multiply scores of paths matching <glob>=<weight> (repeatable)
+        match_count: Annotate each result with how often the query terms occur in it
+        hyperlinks: Make result paths clickable (OSC 8), on by default in a terminal
+        hyperlink_scheme: Link target for --hyperlinks: file (file://) or vscode
+        must_include: Exit non-zero unless this path is among the results (repeatable)
+        from_results: Search a file saved with --output json instead of the server
+        interactive_filter: Narrow the results by path, language or score at a prompt
+        wait_for_index: Wait for a running index of the path to finish before searching
+        skip_index_check: Do not warn when the path is being indexed
+        normalize_query: Trim the query and collapse whitespace before searching
+        lowercase_query: Also lowercase the query with --normalize-query
+        filter_trace: Print how many results each client-side filter dropped to stderr
     """
     from rich import print
 
     from config import load_config
-    from service_factory import ServiceFactory
+    from results import (
+        MAX_LIMIT,
+        clamp_limit,
+        load_search_inputs,
+        parse_query,
+        resolve_search_root,
+        validate_search_args,
+    )
+    from runtime import parse_duration
+    from workspaces import workspace_members, workspace_root
+
+    diagnostics = SearchDiagnostics(verbose, filter_trace, timing_json)
+    try:
+        query, negated_terms = parse_query(
+            query, not_, normalize_query, lowercase_query
+        )
+        limit = clamp_limit(limit)
+        validate_search_args(
+            limit,
+            min_results,
+            limit_per_file,
+            score_precision,
+            depth,
+            max_content_lines,
+            before,
+            after,
+        )
+        since_window = parse_duration(since_index) if since_index else None
+        members = workspace_members(path)
+        root = workspace_root(members) if members else path
+        path = resolve_search_root(root, path_as_is)
+        inputs = load_search_inputs(
+            path, pins_file, boost_path, redact, redact_file, compare_with, from_results
+        )
+    except ValueError as exc:
+        print(str(exc))
+        return
+
+    if limit_per_file is not None and min_results is None:
+        min_results = limit
 
-    collection_name = get_collection_name(path.expanduser().absolute())
+    members = members or [path]
+    collection_names = [get_collection_name(member) for member in members]
+    diagnostics.timer.mark("resolve_path")
 
-    settings, has_changed = load_config(collection_name)
+    member_configs = [load_config(name) for name in collection_names]
+    diagnostics.timer.mark("load_config")
 
-    if has_changed:
+    if any(changed for _, changed in member_configs) and inputs.saved_results is None:
         print("Please first run index command with --force option")
         return
 
-    services = ServiceFactory(settings)
+    params = {
+        "path": str(path),
+        "collection": collection_names[0] if len(members) == 1 else collection_names,
+        "query": query,
+        "limit": limit,
+        "threshold": threshold,
+        "output": output,
+    }
+    diagnostics.print_params(params)
+
+    filter_options = FilterOptions(
+        root=path,
+        query=query,
+        negated_terms=negated_terms,
+        word=word,
+        case_sensitive=case_sensitive,
+        min_depth=min_depth,
+        max_depth=max_depth,
+        since_window=since_window,
+        boosts=inputs.boosts,
+        pin_rules=inputs.pin_rules,
+        drop_stale=drop_stale,
+        limit_per_file=limit_per_file,
+    )
+
+    if explain_query:
+        print_explanation(
+            params,
+            member_configs[0][0],
+            collection_names[0],
+            limit if min_results is None else MAX_LIMIT,
+            filter_options,
+            since_index,
+            inputs.pins_path,
+        )
+        return
 
-    search_service = services.get_search_service()
+    if inputs.saved_results is None and not skip_index_check:
+        await wait_for_indexes(collection_names, wait_for_index, deadline)
 
-    results = await search_service.search(
-        path,
-        query,
-        top_k=limit,
+    search = FanOutSearch(
+        targets=search_targets(members, member_configs, inputs.saved_results),
+        root=path,
+        query=query,
         threshold=threshold,
+        resolve_path=not path_as_is,
+        retry_empty=retry_empty,
+        saved_results=inputs.saved_results,
+        on_retry=diagnostics.report_retry,
     )
+    display = DisplayOptions(
+        root=path,
+        query=query,
+        output=output,
+        normalize_eol=normalize_eol,
+        around_symbol=around_symbol,
+        score_precision=score_precision,
+        redaction_patterns=inputs.redaction_patterns,
+        saved_locations=inputs.saved_locations,
+        summary_only=summary_only,
+        depth=depth,
+        count_by_language=count_by_language,
+        blame=blame,
+        verify_local=verify_local and not drop_stale,
+        match_count=match_count,
+        case_sensitive=case_sensitive,
+        before=before,
+        after=after,
+        include_content_hash=include_content_hash,
+        max_content_lines=max_content_lines,
+        strip_prefix=strip_prefix,
+        auto_strip=auto_strip,
+        hyperlinks=hyperlinks,
+        hyperlink_scheme=hyperlink_scheme,
+        output_dir=output_dir,
+        interactive_filter=interactive_filter,
+    )
+    results = await run_search(
+        search, filter_options, display, diagnostics, limit, min_results, deadline
+    )
+    require_files(results, must_include or [])
+
+
+def print_explanation(
+    params: dict[str, Any],
+    settings: AppSettings,
+    collection_name: str,
+    max_request_limit: int,
+    filter_options: FilterOptions,
+    since_index: str | None,
+    pins_path: Path,
+) -> None:
+    """Print how the query and flags map to the Qdrant request."""
+    from rich.console import Console
+
+    qdrant_url = str(settings.qdrant.url).rstrip("/")
+    pins_file = str(pins_path) if filter_options.pin_rules is not None else None
+    Console().print_json(
+        data={
+            **params,
+            "max_request_limit": max_request_limit,
+            "url": f"{qdrant_url}/collections/{collection_name}/points/query",
+            "code_embedding_model": settings.code_embedding.model,
+            "local_filters": {
+                "word": filter_options.word,
+                "case_sensitive": filter_options.case_sensitive,
+                "min_depth": filter_options.min_depth,
+                "max_depth": filter_options.max_depth,
+                "since_index": since_index,
+                "pins_file": pins_file,
+                "drop_stale": filter_options.drop_stale,
+            },
+        }
+    )
+
+
+async def wait_for_indexes(
+    collection_names: list[str], wait: bool, deadline: datetime | None
+) -> None:
+    """Wait for running indexes of the collections, or warn that one is running."""
+    from rich.console import Console
+
+    from jobs import is_indexing, wait_for_indexing
+    from runtime import run_until
+
+    indexing = [name for name in collection_names if is_indexing(name)]
+    if not indexing:
+        return
+    if not wait:
+        Console(stderr=True).print(
+            "[yellow]Index in progress, results may be incomplete[/yellow]"
+        )
+        return
+    Console(stderr=True).print("Waiting for indexing to finish")
+    async with run_until(deadline):
+        for name in indexing:
+            await wait_for_indexing(name)
+
+
+def search_targets(
+    members: list[Path],
+    member_configs: list[tuple[AppSettings, bool]],
+    saved_results: list[SearchResult] | None,
+) -> list[SearchTarget]:
+    """One search service per workspace member, none when replaying saved results."""
+    from service_factory import ServiceFactory
+
+    if saved_results is not None:
+        return []
+    return [
+        SearchTarget(member, ServiceFactory(settings).get_search_service())
+        for member, (settings, _) in zip(members, member_configs)
+    ]
+
+
+async def run_search(
+    search: FanOutSearch,
+    filter_options: FilterOptions,
+    display: DisplayOptions,
+    diagnostics: SearchDiagnostics,
+    limit: int,
+    min_results: int | None,
+    deadline: datetime | None,
+) -> list[SearchResult]:
+    """Fetch, filter and print the results, returning what was shown."""
+    from results import apply_filters, dispatch_output, fetch_results, prepare_results
+    from runtime import run_until
+
+    async with run_until(deadline):
+        results, rounds = await fetch_results(
+            search,
+            limit,
+            lambda batch: apply_filters(batch, filter_options, diagnostics.trace),
+            min_results,
+        )
+    diagnostics.timer.mark("search")
+    diagnostics.report_search(results, rounds if min_results is not None else None)
+
+    results = prepare_results(results, display)
+    dispatch_output(results, display)
+    diagnostics.timer.mark("output")
+    diagnostics.emit_timings("search")
+    return results
+
+
+def require_files(results: list[SearchResult], must_include: list[str]) -> None:
+    """Exit non-zero when a --must-include path is not among the results."""
+    from rich.console import Console
+
+    from results import missing_files
 
-    print_results(results, output)
+    missing = missing_files(results, must_include)
+    if missing:
+        Console(stderr=True).print(
+            f"[red]Missing required files:[/red] {', '.join(missing)}"
+        )
+        raise SystemExit(1)
@@ -0,0 +1,88 @@
+from pathlib import Path
+from typing import Any
+
+from core import get_collection_name
+from cyclopts import App
+
+config_app = App(name="config", help="Inspect and edit CLI configuration")
+
+SECRET_FIELDS = (
+    ("qdrant", "api_key"),
+    ("code_embedding", "api_key"),
+    ("doc_embedding", "api_key"),
+    ("explainer", "api_key"),
+    ("graph", "password"),
+)
+
+
+def mask_secrets(data: dict[str, Any]) -> dict[str, Any]:
+    for section, key in SECRET_FIELDS:
+        values = data.get(section)
+        if isinstance(values, dict) and values.get(key):
+            values[key] = "***"
+    return data
+
+
+@config_app.command(name="print")
+def config_print_command(path: Path | None = None, show_secrets: bool = False) -> None:
+    """Print the configuration file, or the defaults when there is none, as JSON.
+
+    Environment variables and command-line flags are not merged in. API keys
+    and passwords are masked unless --show-secrets is passed.
+
+    Common invocations:
+
+        code-context config print
+        code-context config print ~/projects/app --show-secrets
+
+    Args:
+        path: Show the configuration stored for an indexed codebase instead
+        show_secrets: Print API keys and passwords instead of masking them
+    """
+    import json
+
+    from rich import print, print_json
+
+    from config import DEFAULT_CONFIG_PATH, AppSettings, load_config
+
+    if not DEFAULT_CONFIG_PATH.exists():
+        print("No configuration found, showing defaults. Run init command first.")
+        settings = AppSettings()
+    else:
+        collection_name = None
+        if path is not None:
+            collection_name = get_collection_name(path.expanduser().absolute())
+        settings, _ = load_config(collection_name)
+
+    data = settings.model_dump(mode="json")
+    print_json(json.dumps(data if show_secrets else mask_secrets(data)))
+
+
+@config_app.command(name="edit")
+def config_edit_command() -> None:
+    """Open the configuration file in $EDITOR, creating it from defaults if missing."""
+    import os
+    import shlex
+    import subprocess
+
+    from pydantic import ValidationError
+    from rich import print
+
+    from config import DEFAULT_CONFIG_PATH, AppSettings, save_config
+
+    if not DEFAULT_CONFIG_PATH.exists():
+        save_config(AppSettings())
+
+    default_editor = "notepad" if os.name == "nt" else "vi"
+    editor = os.environ.get("VISUAL") or os.environ.get("EDITOR") or default_editor
+    command = shlex.split(editor, posix=os.name != "nt")
+    subprocess.run([*command, str(DEFAULT_CONFIG_PATH)], check=False)
+
+    try:
+        settings = AppSettings.model_validate_json(DEFAULT_CONFIG_PATH.read_text())
+    except ValidationError as exc:
+        print(f"[red]Configuration is invalid:[/red] {exc}")
+        return
+
+    save_config(settings)
+    print(f"Configuration saved to {DEFAULT_CONFIG_PATH}")
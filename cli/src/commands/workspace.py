@@ -0,0 +1,105 @@
+from pathlib import Path
+
+from cyclopts import App
+
+workspace_app = App(
+    name="workspace", help="Group indexed paths under a name searched as @name"
+)
+
+
+@workspace_app.command(name="add")
+def workspace_add_command(name: str, *paths: Path) -> None:
+    """Add indexed paths to a workspace, creating it if needed.
+
+    Common invocations:
+
+        code-context workspace add shop ~/projects/shop-api ~/projects/shop-web
+        code-context search "checkout flow" @shop
+
+    Args:
+        name: Workspace name, used as @name in place of a search path
+        paths: Codebase paths to add to the workspace
+    """
+    from rich import print
+
+    from workspaces import load_workspaces, save_workspaces
+
+    if not paths:
+        print("Pass at least one path to add")
+        return
+
+    try:
+        workspaces = load_workspaces()
+    except ValueError as exc:
+        print(str(exc))
+        return
+
+    members = workspaces.setdefault(name.removeprefix("@"), [])
+    for path in paths:
+        member = path.expanduser().absolute()
+        if member not in members:
+            members.append(member)
+    save_workspaces(workspaces)
+    print(f"Workspace @{name.removeprefix('@')}: {len(members)} paths")
+
+
+@workspace_app.command(name="list")
+def workspace_list_command() -> None:
+    """List workspaces and their member paths."""
+    from rich import print
+
+    from workspaces import load_workspaces
+
+    try:
+        workspaces = load_workspaces()
+    except ValueError as exc:
+        print(str(exc))
+        return
+
+    if not workspaces:
+        print("No workspaces defined. Create one with 'workspace add'.")
+        return
+
+    for name, members in sorted(workspaces.items()):
+        print(f"[bold]@{name}[/bold]")
+        for member in members:
+            print(f"  {member}")
+
+
+@workspace_app.command(name="remove")
+def workspace_remove_command(name: str, *paths: Path) -> None:
+    """Remove paths from a workspace, or the whole workspace when none are given.
+
+    Common invocations:
+
+        code-context workspace remove shop ~/projects/shop-web
+        code-context workspace remove shop
+
+    Args:
+        name: Workspace name
+        paths: Member paths to remove, all of them when omitted
+    """
+    from rich import print
+
+    from workspaces import load_workspaces, save_workspaces
+
+    try:
+        workspaces = load_workspaces()
+    except ValueError as exc:
+        print(str(exc))
+        return
+
+    name = name.removeprefix("@")
+    if name not in workspaces:
+        print(f"Workspace @{name} does not exist")
+        raise SystemExit(1)
+
+    removed = {path.expanduser().absolute() for path in paths}
+    members = [member for member in workspaces[name] if member not in removed]
+    if not paths or not members:
+        del workspaces[name]
+        print(f"Workspace @{name} removed")
+    else:
+        workspaces[name] = members
+        print(f"Workspace @{name}: {len(members)} paths")
+    save_workspaces(workspaces)
@@ -1,7 +1,9 @@
 import os
+from collections.abc import Iterator
 from pathlib import Path
 
 import xxhash
+from core import write_text_atomic
 from pydantic import BaseModel, Field, HttpUrl, PositiveInt
 from pydantic_settings import BaseSettings, SettingsConfigDict
 
@@ -13,6 +15,8 @@ DEFAULT_CONFIG_PATH = DEFAULT_DIR / "settings.json"
 CONFIGS_DIR = DEFAULT_DIR / "configs"
 CONFIGS_DIR.mkdir(parents=True, exist_ok=True)
 
+SERVICE_URL_FIELDS = ("qdrant", "code_embedding", "doc_embedding", "explainer")
+
 
 class QdrantConfig(BaseModel):
 
@@ -24,6 +28,18 @@ class QdrantConfig(BaseModel):
         default=None,
         description="Qdrant API key",
     )
+    auth_header: str | None = Field(
+        default="Authorization",
+        description="Header that carries the API key, null for Qdrant's api-key",
+    )
+    auth_scheme: str | None = Field(
+        default="Bearer",
+        description="Scheme prefixed to the API key in auth_header, empty for none",
+    )
+    delete_batch_size: PositiveInt = Field(
+        default=256,
+        description="Files whose stale chunks are deleted per Qdrant request",
+    )
 
 
 class EmbeddingConfig(BaseModel):
@@ -82,6 +98,14 @@ class StorageConfig(BaseModel):
     )
 
 
+class SyncConfig(BaseModel):
+
+    ignore_file: str = Field(
+        default=".code-context-ignore",
+        description="Ignore file (gitignore syntax) applied on top of .gitignore",
+    )
+
+
 class LoggingConfig(BaseModel):
 
     log_file_path: Path = Field(
@@ -145,10 +169,20 @@ class AppSettings(BaseSettings):
     explainer: ExplainerConfig = Field(default_factory=ExplainerConfig)
     chunking: ChunkingConfig = Field(default_factory=ChunkingConfig)
     storage: StorageConfig = Field(default_factory=StorageConfig)
+    sync: SyncConfig = Field(default_factory=SyncConfig)
     logging: LoggingConfig = Field(default_factory=LoggingConfig)
     graph: GraphConfig = Field(default_factory=GraphConfig)
 
 
+def normalize_url(value: str) -> HttpUrl:
+    url = value.strip()
+    if url.startswith(":"):
+        url = f"localhost{url}"
+    if "://" not in url:
+        url = f"http://{url}"
+    return HttpUrl(url)
+
+
 def load_config(collection_name: str | None = None) -> tuple[AppSettings, bool]:
     config_path = DEFAULT_CONFIG_PATH
     hash_path = DEFAULT_DIR / ".settings.hash"
@@ -173,8 +207,55 @@ def save_config(settings: AppSettings, collection_name: str | None = None) -> No
         config_path = CONFIGS_DIR / f"{collection_name}.json"
         hash_path = CONFIGS_DIR / f".{collection_name}.hash"
     output = settings.model_dump_json(indent=2)
-    config_path.write_text(output)
-    hash_path.write_text(xxhash.xxh3_64_hexdigest(output))
+    write_text_atomic(config_path, output)
+    write_text_atomic(hash_path, xxhash.xxh3_64_hexdigest(output))
+
+
+def iter_saved_configs() -> Iterator[tuple[str | None, AppSettings, bool]]:
+    """Yield the global config, then each per-collection config, with its name.
+
+    The global config has no collection name and is skipped when missing.
+    """
+    collections = [path.stem for path in CONFIGS_DIR.glob("*.json")]
+    for collection_name in [None, *collections]:
+        if collection_name is None and not DEFAULT_CONFIG_PATH.exists():
+            continue
+        settings, has_changed = load_config(collection_name)
+        yield collection_name, settings, has_changed
+
+
+def replace_service_urls(replacements: dict[str, HttpUrl]) -> int:
+    """Point the global and per-collection configs at services that moved.
+
+    Configs that were unchanged since their last index stay unchanged, so a
+    moved server does not force a reindex; configs with other pending edits
+    stay marked as changed.
+
+    Returns:
+        Number of config files that were rewritten
+    """
+    updated = 0
+    for collection_name, settings, has_changed in iter_saved_configs():
+        moved = False
+        for name in SERVICE_URL_FIELDS:
+            service = getattr(settings, name)
+            new_url = replacements.get(str(service.url))
+            if new_url is not None:
+                service.url = new_url
+                moved = True
+        if not moved:
+            continue
+        if has_changed:
+            config_path = (
+                DEFAULT_CONFIG_PATH
+                if collection_name is None
+                else CONFIGS_DIR / f"{collection_name}.json"
+            )
+            write_text_atomic(config_path, settings.model_dump_json(indent=2))
+        else:
+            save_config(settings, collection_name)
+        updated += 1
+    return updated
 
 
 def delete_config(collection_name: str) -> None:
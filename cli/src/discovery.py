@@ -0,0 +1,119 @@
+import json
+import re
+import socket
+import subprocess
+from dataclasses import dataclass
+
+from pydantic import HttpUrl
+
+SERVICE_LABEL = "code-context.service"
+
+KNOWN_IMAGES = {"qdrant", "ollama"}
+
+LOCAL_HOSTS = {"localhost", "127.0.0.1"}
+
+_PORT_PATTERN = re.compile(r"(?P<public>\d+)->(?P<private>\d+)/tcp")
+
+
+@dataclass
+class DiscoveredServices:
+    qdrant_url: HttpUrl | None = None
+    ollama_url: HttpUrl | None = None
+
+
+def discover_docker() -> DiscoveredServices:
+    """Locate Qdrant and Ollama containers and their published ports.
+
+    Containers are matched by a ``code-context.service`` label (``qdrant`` or
+    ``ollama``) or by their image name. Nothing is discovered when the docker
+    CLI is missing or the daemon is unreachable.
+    """
+    try:
+        completed = subprocess.run(
+            ["docker", "ps", "--format", "{{json .}}"],
+            capture_output=True,
+            text=True,
+            check=True,
+            timeout=10,
+        )
+    except (OSError, subprocess.SubprocessError):
+        return DiscoveredServices()
+
+    discovered = DiscoveredServices()
+    for line in completed.stdout.splitlines():
+        try:
+            container = json.loads(line)
+        except ValueError:
+            continue
+        service = _service_of(container)
+        if service == "qdrant" and discovered.qdrant_url is None:
+            discovered.qdrant_url = _published_url(container, 6333)
+        elif service == "ollama" and discovered.ollama_url is None:
+            url = _published_url(container, 11434)
+            discovered.ollama_url = HttpUrl(f"{url}v1") if url is not None else None
+    return discovered
+
+
+def _service_of(container: dict[str, str]) -> str | None:
+    labels = dict(
+        label.split("=", 1)
+        for label in container.get("Labels", "").split(",")
+        if "=" in label
+    )
+    if SERVICE_LABEL in labels:
+        return labels[SERVICE_LABEL]
+    image = container.get("Image", "").split(":", 1)[0].rsplit("/", 1)[-1]
+    return image if image in KNOWN_IMAGES else None
+
+
+def _published_url(container: dict[str, str], private_port: int) -> HttpUrl | None:
+    for match in _PORT_PATTERN.finditer(container.get("Ports", "")):
+        if int(match.group("private")) == private_port:
+            return HttpUrl(f"http://localhost:{match.group('public')}")
+    return None
+
+
+def rediscover_services() -> dict[str, HttpUrl]:
+    """Re-run docker discovery for configured local services that refuse connections.
+
+    URLs are collected from the global and every per-collection config, and
+    each config using an unreachable one is repointed at the port its
+    container publishes now.
+
+    Returns:
+        Map of each replaced URL to its new value, empty when nothing moved
+    """
+    from config import SERVICE_URL_FIELDS, iter_saved_configs, replace_service_urls
+
+    configured: dict[str, tuple[str, HttpUrl]] = {}
+    for _, settings, _ in iter_saved_configs():
+        for name in SERVICE_URL_FIELDS:
+            url = getattr(settings, name).url
+            configured.setdefault(str(url), (name, url))
+    if not configured:
+        return {}
+
+    discovered = discover_docker()
+    moved: dict[str, HttpUrl] = {}
+    for current_text, (name, current) in configured.items():
+        found = discovered.qdrant_url if name == "qdrant" else discovered.ollama_url
+        if (
+            found is not None
+            and current != found
+            and current.host in LOCAL_HOSTS
+            and not _is_reachable(current)
+        ):
+            moved[current_text] = found
+    if moved:
+        replace_service_urls(moved)
+    return moved
+
+
+def _is_reachable(url: HttpUrl) -> bool:
+    if url.host is None or url.port is None:
+        return True
+    try:
+        with socket.create_connection((url.host, url.port), timeout=1):
+            return True
+    except OSError:
+        return False
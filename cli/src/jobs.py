@@ -0,0 +1,52 @@
+import asyncio
+import os
+from collections.abc import Iterator
+from contextlib import contextmanager
+from pathlib import Path
+
+from core import write_text_atomic
+
+from config import CONFIGS_DIR
+
+INDEX_POLL_SECONDS = 1.0
+
+
+def _marker_path(collection_name: str) -> Path:
+    return CONFIGS_DIR / f".{collection_name}.indexing"
+
+
+@contextmanager
+def indexing_job(collection_name: str) -> Iterator[None]:
+    """Mark the collection as being indexed by this process until the block exits."""
+    marker = _marker_path(collection_name)
+    write_text_atomic(marker, str(os.getpid()))
+    try:
+        yield
+    finally:
+        marker.unlink(missing_ok=True)
+
+
+def is_indexing(collection_name: str) -> bool:
+    """Whether a live process is indexing the collection.
+
+    Markers left behind by a process that died without cleaning up are ignored,
+    except on Windows where the owner cannot be probed without side effects.
+    """
+    try:
+        pid = int(_marker_path(collection_name).read_text(encoding="utf-8"))
+    except (OSError, ValueError):
+        return False
+    if os.name == "nt":
+        return True
+    try:
+        os.kill(pid, 0)
+    except ProcessLookupError:
+        return False
+    except PermissionError:
+        return True
+    return True
+
+
+async def wait_for_indexing(collection_name: str) -> None:
+    while is_indexing(collection_name):
+        await asyncio.sleep(INDEX_POLL_SECONDS)
@@ -1,11 +1,19 @@
-from cyclopts import App
+from typing import Annotated, Literal
+
+from cyclopts import App, Parameter
 
 from commands import (
+    bench_command,
+    check_command,
+    config_app,
     drop_command,
+    export_command,
     index_command,
     init_command,
     mcp_command,
+    schema_command,
     search_command,
+    workspace_app,
 )
 
 
@@ -24,6 +32,12 @@ app.command(index_command, name="index")
 app.command(search_command, name="search")
 app.command(drop_command, name="drop")
 app.command(mcp_command, name="mcp")
+app.command(check_command, name="check")
+app.command(config_app)
+app.command(workspace_app)
+app.command(schema_command, name="schema")
+app.command(export_command, name="export")
+app.command(bench_command, name="bench", show=False)
 
 
 @app.default
@@ -35,5 +49,92 @@ async def default_command() -> None:
     print("Get started with 'code-context init' to configure the CLI.")
 
 
+@app.meta.default
+def launcher(
+    *tokens: Annotated[str, Parameter(show=False, allow_leading_hyphen=True)],
+    max_runtime: str | None = None,
+    retry_jitter: Literal["full", "none"] = "full",
+) -> None:
+    """Semantic code search CLI.
+
+    Args:
+        max_runtime: Hard ceiling on total runtime (e.g. 90s, 10m), exits non-zero
+        retry_jitter: Randomize rate limit retry backoff (full) or use fixed delays
+    """
+    import asyncio
+    import inspect
+
+    from rich import print
+
+    from runtime import parse_duration, run_within
+
+    if retry_jitter != "full":
+        from core import configure_retry_jitter
+
+        configure_retry_jitter(retry_jitter)
+
+    if max_runtime is None:
+        app(tokens)
+        return
+
+    try:
+        limit = parse_duration(max_runtime)
+    except ValueError as exc:
+        print(f"[red]{exc}[/red]")
+        raise SystemExit(2)
+
+    command, bound, _ = app.parse_args(tokens)
+    result = command(*bound.args, **bound.kwargs)
+    if inspect.iscoroutine(result):
+        asyncio.run(run_within(result, limit))
+
+
+def run(rediscover: bool = True) -> None:
+    """Run the CLI, retrying once against rediscovered services on refusal.
+
+    Args:
+        rediscover: Whether a refused connection may trigger rediscovery
+    """
+    try:
+        app.meta()
+        return
+    except Exception as exc:
+        from loguru import logger
+        from rich import print
+
+        from runtime import is_clock_skew_error, is_connection_refused
+
+        if is_connection_refused(exc):
+            logger.opt(exception=exc).debug("Connection refused")
+            if not rediscover:
+                print(
+                    "[red]Connection still refused after rediscovery[/red] "
+                    "- check that Qdrant and the embedding service are running"
+                )
+                raise SystemExit(1)
+
+            from discovery import rediscover_services
+
+            moved = rediscover_services()
+            if not moved:
+                print(
+                    "[red]Connection refused and no moved service was discovered[/red] "
+                    "- check that Qdrant and the embedding service are running"
+                )
+                raise SystemExit(1)
+            for old_url, new_url in moved.items():
+                print(f"[yellow]{old_url} is not reachable, using {new_url}[/yellow]")
+        elif not is_clock_skew_error(exc):
+            raise
+        else:
+            logger.opt(exception=exc).debug("TLS certificate validity error")
+            print(
+                "[red]TLS certificate error - check your system clock[/red] "
+                "(details are in the debug log)"
+            )
+            raise SystemExit(1)
+    run(rediscover=False)
+
+
 if __name__ == "__main__":
-    app()
+    run()
@@ -0,0 +1,200 @@
+from .blame import BlameCache, annotate_blame
+from .compare import (
+    RankChange,
+    ResultDiff,
+    diff_results,
+    load_saved_locations,
+    print_diff,
+)
+from .context import ResultContext, read_context, render_with_context
+from .diagnostics import PhaseTimer, SearchDiagnostics
+from .dispatch import (
+    DisplayOptions,
+    annotate_results,
+    dispatch_output,
+    prepare_results,
+    render_options,
+    render_results,
+)
+from .fanout import (
+    MAX_LIMIT,
+    FanOutSearch,
+    SearchTarget,
+    fetch_results,
+    rebase_results,
+)
+from .filters import (
+    boost_paths,
+    count_term_matches,
+    filter_by_depth,
+    filter_indexed_since,
+    filter_negated_terms,
+    filter_per_file,
+    filter_whole_words,
+    matches_any_glob,
+    missing_files,
+    normalize_query_text,
+    parse_boost,
+    split_negated_terms,
+    term_patterns,
+)
+from .inputs import (
+    SearchInputs,
+    clamp_limit,
+    load_search_inputs,
+    parse_query,
+    resolve_search_root,
+    validate_search_args,
+)
+from .interactive import (
+    FilterExpression,
+    matching_indices,
+    parse_filter_expression,
+    run_interactive_filter,
+)
+from .output import (
+    Annotations,
+    HyperlinkScheme,
+    OutputType,
+    RenderOptions,
+    common_directory,
+    content_hash,
+    ctags_format,
+    format_results,
+    has_mixed_line_endings,
+    hyperlink_uri,
+    json_format,
+    json_format_simple,
+    normalize_line_endings,
+    plain_format,
+    preview,
+    print_results,
+    quickfix_format,
+    round_scores,
+    sarif_format,
+    select_results,
+    strip_path,
+    supports_hyperlinks,
+    write_output_dir,
+)
+from .pipeline import FilterOptions, apply_filters
+from .pins import PINS_FILE_NAME, PinRules, apply_pin_rules, load_pin_rules
+from .redact import (
+    default_redaction_patterns,
+    load_redaction_patterns,
+    redact_context,
+    redact_results,
+    redact_text,
+)
+from .saved import load_saved_results, replay_search
+from .summary import (
+    DirectorySummary,
+    LanguageCount,
+    print_language_counts,
+    print_summary,
+    summarize_by_directory,
+    summarize_by_language,
+)
+from .symbols import expand_results_to_symbols, expand_to_symbol
+from .trace import FilterStage, FilterTrace, print_filter_trace
+from .trim import TrimmedContent, trim_content
+from .verify import check_local
+
+__all__ = [
+    "Annotations",
+    "HyperlinkScheme",
+    "BlameCache",
+    "DirectorySummary",
+    "LanguageCount",
+    "OutputType",
+    "PINS_FILE_NAME",
+    "PinRules",
+    "RankChange",
+    "RenderOptions",
+    "ResultContext",
+    "ResultDiff",
+    "TrimmedContent",
+    "annotate_blame",
+    "apply_pin_rules",
+    "boost_paths",
+    "check_local",
+    "common_directory",
+    "content_hash",
+    "default_redaction_patterns",
+    "ctags_format",
+    "diff_results",
+    "expand_results_to_symbols",
+    "expand_to_symbol",
+    "filter_by_depth",
+    "filter_indexed_since",
+    "filter_negated_terms",
+    "filter_per_file",
+    "filter_whole_words",
+    "count_term_matches",
+    "format_results",
+    "has_mixed_line_endings",
+    "json_format",
+    "json_format_simple",
+    "load_pin_rules",
+    "load_redaction_patterns",
+    "load_saved_locations",
+    "matches_any_glob",
+    "missing_files",
+    "normalize_query_text",
+    "normalize_line_endings",
+    "parse_boost",
+    "plain_format",
+    "preview",
+    "print_diff",
+    "print_language_counts",
+    "print_results",
+    "print_summary",
+    "quickfix_format",
+    "read_context",
+    "redact_context",
+    "redact_results",
+    "redact_text",
+    "load_saved_results",
+    "replay_search",
+    "round_scores",
+    "sarif_format",
+    "split_negated_terms",
+    "strip_path",
+    "render_with_context",
+    "summarize_by_directory",
+    "summarize_by_language",
+    "term_patterns",
+    "trim_content",
+    "write_output_dir",
+    "hyperlink_uri",
+    "supports_hyperlinks",
+    "select_results",
+    "FilterExpression",
+    "matching_indices",
+    "parse_filter_expression",
+    "run_interactive_filter",
+    "FilterStage",
+    "FilterTrace",
+    "print_filter_trace",
+    "DisplayOptions",
+    "annotate_results",
+    "dispatch_output",
+    "prepare_results",
+    "render_options",
+    "render_results",
+    "MAX_LIMIT",
+    "FanOutSearch",
+    "SearchTarget",
+    "fetch_results",
+    "rebase_results",
+    "FilterOptions",
+    "apply_filters",
+    "PhaseTimer",
+    "SearchDiagnostics",
+    "SearchInputs",
+    "clamp_limit",
+    "load_search_inputs",
+    "parse_query",
+    "resolve_search_root",
+    "validate_search_args",
+]
@@ -0,0 +1,91 @@
+import subprocess
+from dataclasses import dataclass
+from pathlib import Path
+
+from core import SearchResult
+
+
+@dataclass
+class BlameLine:
+    commit: str
+    author: str
+    author_time: int
+
+
+class BlameCache:
+
+    def __init__(self, root: Path) -> None:
+        self.root = root
+        self._files: dict[str, list[BlameLine] | None] = {}
+
+    def annotate(
+        self, relative_path: str, start_line: int, end_line: int
+    ) -> str | None:
+        """Describe the most recent change touching a line range.
+
+        Args:
+            relative_path: File path relative to the blame root
+            start_line: First line of the range (1-based)
+            end_line: Last line of the range (inclusive)
+
+        Returns:
+            Author and short commit of the latest change, or None if unavailable
+        """
+        if relative_path not in self._files:
+            self._files[relative_path] = _run_blame(self.root, relative_path)
+
+        lines = self._files[relative_path] or []
+        selected = lines[max(start_line - 1, 0) : end_line]
+        if not selected:
+            return None
+
+        latest = max(selected, key=lambda line: line.author_time)
+        return f"{latest.author} ({latest.commit[:8]})"
+
+
+def annotate_blame(
+    results: list[SearchResult], root: Path, annotations: list[dict[str, str | int]]
+) -> None:
+    blame_cache = BlameCache(root)
+    for result, annotation in zip(results, annotations):
+        author = blame_cache.annotate(
+            result.relative_path, result.start_line, result.end_line
+        )
+        if author is not None:
+            annotation["blame"] = author
+
+
+def _run_blame(root: Path, relative_path: str) -> list[BlameLine] | None:
+    if not (root / relative_path).is_file():
+        return None
+    try:
+        completed = subprocess.run(
+            ["git", "blame", "--line-porcelain", "--", relative_path],
+            cwd=root,
+            capture_output=True,
+            text=True,
+            check=True,
+        )
+    except (OSError, subprocess.CalledProcessError):
+        return None
+    return _parse_porcelain(completed.stdout)
+
+
+def _parse_porcelain(output: str) -> list[BlameLine]:
+    lines: list[BlameLine] = []
+    commit, author, author_time = "", "", 0
+    expect_header = True
+
+    for raw_line in output.splitlines():
+        if raw_line.startswith("\t"):
+            lines.append(BlameLine(commit, author, author_time))
+            expect_header = True
+        elif expect_header:
+            commit = raw_line.split(" ", 1)[0]
+            expect_header = False
+        elif raw_line.startswith("author "):
+            author = raw_line.removeprefix("author ")
+        elif raw_line.startswith("author-time "):
+            author_time = int(raw_line.removeprefix("author-time "))
+
+    return lines
@@ -0,0 +1,87 @@
+import json
+from dataclasses import dataclass, field
+from pathlib import Path
+
+from core import SearchResult
+
+
+@dataclass
+class RankChange:
+    location: str
+    old_rank: int
+    new_rank: int
+
+
+@dataclass
+class ResultDiff:
+    added: list[str] = field(default_factory=list)
+    removed: list[str] = field(default_factory=list)
+    moved: list[RankChange] = field(default_factory=list)
+    unchanged: int = 0
+
+
+def result_location(relative_path: str, start_line: int, end_line: int) -> str:
+    return f"{relative_path}:{start_line}-{end_line}"
+
+
+def load_saved_locations(path: Path) -> list[str]:
+    """Read result locations from a file saved with ``search --output json``.
+
+    Raises:
+        ValueError: If the file is not a saved json result set
+    """
+    try:
+        data = json.loads(path.read_text(encoding="utf-8"))
+        return [
+            result_location(item["relative_path"], item["start_line"], item["end_line"])
+            for item in data["results"]
+        ]
+    except (OSError, ValueError, KeyError, TypeError) as exc:
+        raise ValueError(f"Invalid saved results file {path}: {exc}") from exc
+
+
+def diff_results(saved: list[str], results: list[SearchResult]) -> ResultDiff:
+    current = [
+        result_location(result.relative_path, result.start_line, result.end_line)
+        for result in results
+    ]
+    old_ranks = {location: rank for rank, location in enumerate(saved, 1)}
+    new_ranks = {location: rank for rank, location in enumerate(current, 1)}
+
+    diff = ResultDiff(
+        added=[location for location in current if location not in old_ranks],
+        removed=[location for location in saved if location not in new_ranks],
+    )
+    for location, new_rank in new_ranks.items():
+        old_rank = old_ranks.get(location)
+        if old_rank is None:
+            continue
+        if old_rank == new_rank:
+            diff.unchanged += 1
+        else:
+            diff.moved.append(RankChange(location, old_rank, new_rank))
+    return diff
+
+
+def print_diff(diff: ResultDiff, as_json: bool = False) -> None:
+    from dataclasses import asdict
+
+    from rich import print, print_json
+
+    if as_json:
+        print_json(json.dumps(asdict(diff)))
+        return
+
+    for location in diff.added:
+        print(f"[green]+ {location}[/green]")
+    for location in diff.removed:
+        print(f"[red]- {location}[/red]")
+    for change in diff.moved:
+        print(
+            f"[yellow]~ {change.location}[/yellow] "
+            f"#{change.old_rank} -> #{change.new_rank}"
+        )
+    print(
+        f"{len(diff.added)} added, {len(diff.removed)} removed, "
+        f"{len(diff.moved)} moved, {diff.unchanged} unchanged"
+    )
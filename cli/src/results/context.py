@@ -0,0 +1,41 @@
+from dataclasses import dataclass
+from pathlib import Path
+
+from core import SearchResult
+from rich.syntax import Syntax
+
+
+@dataclass
+class ResultContext:
+    before: list[str]
+    after: list[str]
+
+
+def read_context(
+    root: Path, result: SearchResult, before: int, after: int
+) -> ResultContext | None:
+    try:
+        text = (root / result.relative_path).read_text(encoding="utf-8")
+    except (OSError, UnicodeDecodeError):
+        return None
+
+    lines = text.splitlines()
+    first_line = max(result.start_line - 1 - before, 0)
+    return ResultContext(
+        before=lines[first_line : max(result.start_line - 1, 0)],
+        after=lines[result.end_line : result.end_line + after],
+    )
+
+
+def render_with_context(result: SearchResult, context: ResultContext | None) -> Syntax:
+    if context is None:
+        return Syntax(result.content.strip(), result.language, line_numbers=False)
+
+    lines = [*context.before, *result.content.splitlines(), *context.after]
+    return Syntax(
+        "\n".join(lines),
+        result.language,
+        line_numbers=True,
+        start_line=result.start_line - len(context.before),
+        highlight_lines=set(range(result.start_line, result.end_line + 1)),
+    )
@@ -0,0 +1,83 @@
+import json
+import sys
+import time
+from dataclasses import dataclass, field
+from typing import Any
+
+from core import SearchResult
+
+from .output import has_mixed_line_endings
+from .trace import FilterTrace, print_filter_trace
+
+
+class PhaseTimer:
+
+    def __init__(self) -> None:
+        self.phases: dict[str, float] = {}
+        self._started = time.perf_counter()
+        self._last = self._started
+
+    def mark(self, phase: str) -> None:
+        now = time.perf_counter()
+        self.phases[phase] = self.phases.get(phase, 0.0) + now - self._last
+        self._last = now
+
+    def emit(self, command: str) -> None:
+        payload = {
+            "command": command,
+            "phases_ms": {
+                phase: round(seconds * 1000, 3)
+                for phase, seconds in self.phases.items()
+            },
+            "total_ms": round((self._last - self._started) * 1000, 3),
+        }
+        print(json.dumps(payload), file=sys.stderr)
+
+
+@dataclass
+class SearchDiagnostics:
+    """Stderr reports requested with --verbose, --filter-trace and --timing-json."""
+
+    verbose: bool = False
+    filter_trace: bool = False
+    timing_json: bool = False
+    timer: PhaseTimer = field(default_factory=PhaseTimer)
+    trace: FilterTrace = field(default_factory=FilterTrace)
+
+    def print_params(self, params: dict[str, Any]) -> None:
+        from rich.console import Console
+
+        if self.verbose:
+            Console(stderr=True).print_json(data=params)
+
+    def report_retry(self, attempt: int) -> None:
+        from rich.console import Console
+
+        if self.verbose:
+            Console(stderr=True).print(f"Empty result, retry {attempt}")
+
+    def report_search(self, results: list[SearchResult], rounds: int | None) -> None:
+        """Report escalation rounds, the filter funnel and mixed line endings.
+
+        Args:
+            rounds: Limit escalation rounds, None when --min-results was not used
+        """
+        from rich.console import Console
+
+        console = Console(stderr=True)
+        if self.verbose and rounds is not None:
+            console.print(f"Limit escalation rounds: {rounds}")
+        if self.filter_trace:
+            self.trace.add("limit", self.trace.remaining, len(results))
+            print_filter_trace(self.trace)
+        if not self.verbose:
+            return
+        for result in results:
+            if has_mixed_line_endings(result.content):
+                console.print(
+                    f"Mixed line endings in {result.relative_path}:{result.start_line}"
+                )
+
+    def emit_timings(self, command: str) -> None:
+        if self.timing_json:
+            self.timer.emit(command)
@@ -0,0 +1,156 @@
+import re
+from dataclasses import dataclass
+from pathlib import Path
+
+from core import SearchResult
+
+from .blame import annotate_blame
+from .compare import diff_results, print_diff
+from .context import read_context
+from .filters import count_term_matches
+from .interactive import run_interactive_filter
+from .output import (
+    Annotations,
+    HyperlinkScheme,
+    OutputType,
+    RenderOptions,
+    common_directory,
+    content_hash,
+    normalize_line_endings,
+    print_results,
+    round_scores,
+    supports_hyperlinks,
+    write_output_dir,
+)
+from .redact import redact_context, redact_results
+from .summary import (
+    print_language_counts,
+    print_summary,
+    summarize_by_directory,
+    summarize_by_language,
+)
+from .symbols import expand_results_to_symbols
+from .verify import check_local
+
+
+@dataclass
+class DisplayOptions:
+    root: Path
+    query: str
+    output: OutputType = "simple"
+    normalize_eol: bool = False
+    around_symbol: bool = False
+    score_precision: int | None = None
+    redaction_patterns: list[re.Pattern[str]] | None = None
+    saved_locations: list[str] | None = None
+    summary_only: bool = False
+    depth: int = 1
+    count_by_language: bool = False
+    blame: bool = False
+    verify_local: bool = False
+    match_count: bool = False
+    case_sensitive: bool = False
+    before: int = 0
+    after: int = 0
+    include_content_hash: bool = False
+    max_content_lines: int | None = None
+    strip_prefix: str | None = None
+    auto_strip: bool = False
+    hyperlinks: bool | None = None
+    hyperlink_scheme: HyperlinkScheme = "file"
+    output_dir: Path | None = None
+    interactive_filter: bool = False
+
+
+def prepare_results(
+    results: list[SearchResult], options: DisplayOptions
+) -> list[SearchResult]:
+    """Apply the content and score transforms that run after filtering.
+
+    Redaction is left to rendering so local checks and hashes see the indexed
+    content.
+    """
+    if options.normalize_eol:
+        results = normalize_line_endings(results)
+    if options.around_symbol:
+        results = expand_results_to_symbols(options.root, results)
+    if options.score_precision is not None:
+        results = round_scores(results, options.score_precision)
+    return results
+
+
+def annotate_results(
+    results: list[SearchResult], options: DisplayOptions
+) -> Annotations:
+    """Annotate results before redaction, hashing the content here when redacting."""
+    annotations: Annotations = [{} for _ in results]
+    if options.include_content_hash and options.redaction_patterns is not None:
+        for result, annotation in zip(results, annotations):
+            annotation["content_hash"] = content_hash(result)
+    if options.blame:
+        annotate_blame(results, options.root, annotations)
+    if options.verify_local:
+        for result, annotation in zip(results, annotations):
+            status = check_local(options.root, result)
+            if status is not None:
+                annotation["local_check"] = status
+    if options.match_count:
+        for result, annotation in zip(results, annotations):
+            annotation["matches"] = count_term_matches(
+                result.content, options.query, options.case_sensitive
+            )
+    return annotations
+
+
+def render_options(
+    results: list[SearchResult], options: DisplayOptions
+) -> RenderOptions:
+    contexts = None
+    if options.before or options.after:
+        contexts = [
+            read_context(options.root, r, options.before, options.after)
+            for r in results
+        ]
+        if options.redaction_patterns is not None:
+            contexts = [redact_context(c, options.redaction_patterns) for c in contexts]
+    use_hyperlinks = (
+        supports_hyperlinks() if options.hyperlinks is None else options.hyperlinks
+    )
+    return RenderOptions(
+        options.root,
+        options.include_content_hash and options.redaction_patterns is None,
+        annotate_results(results, options),
+        contexts,
+        options.max_content_lines,
+        options.query,
+        common_directory(results) if options.auto_strip else options.strip_prefix,
+        options.hyperlink_scheme if use_hyperlinks else None,
+    )
+
+
+def dispatch_output(results: list[SearchResult], options: DisplayOptions) -> None:
+    """Print the diff, summary, language counts or results that were asked for."""
+    as_json = options.output != "simple"
+    if options.saved_locations is not None:
+        print_diff(diff_results(options.saved_locations, results), as_json)
+    elif options.summary_only:
+        print_summary(summarize_by_directory(results, options.depth), as_json)
+    elif options.count_by_language:
+        print_language_counts(summarize_by_language(results), as_json)
+    else:
+        render_results(results, options)
+
+
+def render_results(results: list[SearchResult], options: DisplayOptions) -> None:
+    from rich import print
+
+    render = render_options(results, options)
+    if options.redaction_patterns is not None:
+        results = redact_results(results, options.redaction_patterns)
+    if options.output_dir is not None:
+        written = write_output_dir(results, options.output, render, options.output_dir)
+        print(f"Wrote {written} files to {options.output_dir}")
+    elif options.interactive_filter:
+        run_interactive_filter(results, options.output, render)
+    else:
+        print_results(results, options.output, render)
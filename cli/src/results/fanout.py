@@ -0,0 +1,106 @@
+import asyncio
+from collections.abc import Awaitable, Callable
+from dataclasses import dataclass, replace
+from pathlib import Path
+
+from core import SearchResult, SearchService
+
+from .saved import replay_search
+
+ResultFilter = Callable[[list[SearchResult]], list[SearchResult]]
+
+MAX_LIMIT = 50
+
+EMPTY_RETRIES = 2
+EMPTY_RETRY_DELAY_SECONDS = 1.0
+
+
+@dataclass
+class SearchTarget:
+    root: Path
+    service: SearchService
+
+
+def rebase_results(
+    results: list[SearchResult], member: Path, root: Path
+) -> list[SearchResult]:
+    """Make result paths relative to the workspace root instead of their member."""
+    return [
+        replace(
+            result,
+            relative_path=(member / result.relative_path).relative_to(root).as_posix(),
+        )
+        for result in results
+    ]
+
+
+@dataclass
+class FanOutSearch:
+    """Search every target and merge the results by score under a shared root.
+
+    Saved results, when given, answer the search instead of the server.
+    """
+
+    targets: list[SearchTarget]
+    root: Path
+    query: str
+    threshold: float = 0.0
+    resolve_path: bool = True
+    retry_empty: bool = False
+    saved_results: list[SearchResult] | None = None
+    on_retry: Callable[[int], None] | None = None
+
+    async def __call__(self, top_k: int) -> list[SearchResult]:
+        if self.saved_results is not None:
+            return replay_search(self.saved_results, top_k, self.threshold)
+        for attempt in range(EMPTY_RETRIES + 1 if self.retry_empty else 1):
+            if attempt:
+                await asyncio.sleep(EMPTY_RETRY_DELAY_SECONDS)
+                if self.on_retry is not None:
+                    self.on_retry(attempt)
+            results = await self.search_targets(top_k)
+            if results:
+                break
+        return results
+
+    async def search_targets(self, top_k: int) -> list[SearchResult]:
+        merged: list[SearchResult] = []
+        for target in self.targets:
+            target_results = await target.service.search(
+                target.root,
+                self.query,
+                top_k=top_k,
+                threshold=self.threshold,
+                resolve_path=self.resolve_path,
+            )
+            if len(self.targets) == 1:
+                return target_results
+            merged.extend(rebase_results(target_results, target.root, self.root))
+        return sorted(merged, key=lambda result: -result.score)[:top_k]
+
+
+async def fetch_results(
+    search: Callable[[int], Awaitable[list[SearchResult]]],
+    limit: int,
+    apply_filters: ResultFilter,
+    min_results: int | None = None,
+) -> tuple[list[SearchResult], int]:
+    """Search and filter, doubling the limit until enough results remain.
+
+    Returns:
+        Filtered results and the number of escalation rounds performed
+    """
+    top_k = limit
+    rounds = 0
+    while True:
+        raw_results = await search(top_k)
+        results = apply_filters(raw_results)
+        if (
+            min_results is None
+            or len(results) >= min_results
+            or len(raw_results) < top_k
+            or top_k >= MAX_LIMIT
+        ):
+            return results[:limit], rounds
+        top_k = min(top_k * 2, MAX_LIMIT)
+        rounds += 1
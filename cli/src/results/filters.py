@@ -0,0 +1,185 @@
+import fnmatch
+import re
+from dataclasses import replace
+from datetime import datetime, timedelta, timezone
+from pathlib import PurePath
+
+from core import SearchResult
+
+
+def matches_any_glob(relative_path: str, patterns: list[str]) -> bool:
+    return any(PurePath(relative_path).full_match(pattern) for pattern in patterns)
+
+
+def has_unclosed_bracket(glob: str) -> bool:
+    index = 0
+    while (index := glob.find("[", index)) != -1:
+        # A "]" right after "[" or "[!" is a literal member of the set
+        start = index + 1
+        if glob[start : start + 1] == "!":
+            start += 1
+        close = glob.find("]", start + 1)
+        if close == -1:
+            return True
+        index = close + 1
+    return False
+
+
+def validate_glob(glob: str) -> None:
+    """Reject globs with an unclosed ``[`` set or that do not compile.
+
+    Raises:
+        ValueError: If the glob is invalid
+    """
+    if has_unclosed_bracket(glob):
+        raise ValueError(f"Invalid glob {glob!r}, unclosed '['")
+    try:
+        re.compile(fnmatch.translate(glob))
+    except re.error as exc:
+        raise ValueError(f"Invalid glob {glob!r}: {exc}") from None
+
+
+def parse_boost(value: str) -> tuple[str, float]:
+    """Parse a ``<glob>=<weight>`` path boost.
+
+    Raises:
+        ValueError: If the glob is invalid or the weight is not a positive number
+    """
+    glob, separator, weight_text = value.rpartition("=")
+    if not separator or not glob:
+        raise ValueError(f"Invalid boost {value!r}, expected <glob>=<weight>")
+    try:
+        weight = float(weight_text)
+    except ValueError:
+        raise ValueError(f"Invalid boost weight in {value!r}") from None
+    if weight <= 0:
+        raise ValueError(f"Boost weight must be positive in {value!r}")
+    validate_glob(glob)
+    return glob, weight
+
+
+def boost_paths(
+    results: list[SearchResult], boosts: list[tuple[str, float]]
+) -> list[SearchResult]:
+    boosted = []
+    for result in results:
+        score = result.score
+        for glob, weight in boosts:
+            if PurePath(result.relative_path).full_match(glob):
+                score *= weight
+        boosted.append(replace(result, score=score))
+    return sorted(boosted, key=lambda result: -result.score)
+
+
+def term_patterns(query: str, case_sensitive: bool = False) -> list[re.Pattern[str]]:
+    flags = 0 if case_sensitive else re.IGNORECASE
+    return [
+        re.compile(rf"\b{re.escape(term)}\b", flags)
+        for term in re.findall(r"\w+", query)
+    ]
+
+
+def filter_whole_words(
+    results: list[SearchResult], query: str, case_sensitive: bool = False
+) -> list[SearchResult]:
+    patterns = term_patterns(query, case_sensitive)
+    return [
+        result
+        for result in results
+        if all(pattern.search(result.content) for pattern in patterns)
+    ]
+
+
+def count_term_matches(content: str, query: str, case_sensitive: bool = False) -> int:
+    patterns = term_patterns(query, case_sensitive)
+    return sum(len(pattern.findall(content)) for pattern in patterns)
+
+
+def normalize_query_text(query: str, lowercase: bool = False) -> str:
+    """Trim the query and collapse runs of whitespace to single spaces."""
+    normalized = " ".join(query.split())
+    return normalized.lower() if lowercase else normalized
+
+
+def split_negated_terms(query: str) -> tuple[str, list[str]]:
+    """Separate ``-term`` tokens from the rest of the query."""
+    kept: list[str] = []
+    negated: list[str] = []
+    for token in query.split():
+        if len(token) > 1 and token.startswith("-"):
+            negated.append(token[1:])
+        else:
+            kept.append(token)
+    return " ".join(kept), negated
+
+
+def filter_negated_terms(
+    results: list[SearchResult],
+    terms: list[str],
+    whole_word: bool = False,
+    case_sensitive: bool = False,
+) -> list[SearchResult]:
+    flags = 0 if case_sensitive else re.IGNORECASE
+    patterns = [
+        re.compile(rf"\b{re.escape(term)}\b" if whole_word else re.escape(term), flags)
+        for term in terms
+    ]
+    return [
+        result
+        for result in results
+        if not any(
+            pattern.search(result.content) or pattern.search(result.relative_path)
+            for pattern in patterns
+        )
+    ]
+
+
+def filter_by_depth(
+    results: list[SearchResult],
+    min_depth: int | None = None,
+    max_depth: int | None = None,
+) -> list[SearchResult]:
+    filtered: list[SearchResult] = []
+    for result in results:
+        depth = len(PurePath(result.relative_path).parts) - 1
+        if min_depth is not None and depth < min_depth:
+            continue
+        if max_depth is not None and depth > max_depth:
+            continue
+        filtered.append(result)
+    return filtered
+
+
+def filter_indexed_since(
+    results: list[SearchResult], window: timedelta
+) -> list[SearchResult]:
+    cutoff = datetime.now(timezone.utc) - window
+    filtered: list[SearchResult] = []
+    for result in results:
+        if result.indexed_at is None:
+            filtered.append(result)
+            continue
+        indexed_at = datetime.fromisoformat(result.indexed_at)
+        if indexed_at.tzinfo is None:
+            indexed_at = indexed_at.replace(tzinfo=timezone.utc)
+        if indexed_at >= cutoff:
+            filtered.append(result)
+    return filtered
+
+
+def missing_files(results: list[SearchResult], required: list[str]) -> list[str]:
+    found = {PurePath(result.relative_path).as_posix() for result in results}
+    return [path for path in required if PurePath(path).as_posix() not in found]
+
+
+def filter_per_file(
+    results: list[SearchResult], max_per_file: int
+) -> list[SearchResult]:
+    counts: dict[str, int] = {}
+    kept = []
+    for result in results:
+        count = counts.get(result.relative_path, 0)
+        if count < max_per_file:
+            counts[result.relative_path] = count + 1
+            kept.append(result)
+    return kept
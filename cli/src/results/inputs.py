@@ -0,0 +1,136 @@
+import re
+from dataclasses import dataclass, field
+from pathlib import Path
+
+from core import SearchResult
+
+from .compare import load_saved_locations
+from .fanout import MAX_LIMIT
+from .filters import normalize_query_text, parse_boost, split_negated_terms
+from .pins import PINS_FILE_NAME, PinRules, load_pin_rules
+from .redact import default_redaction_patterns, load_redaction_patterns
+from .saved import load_saved_results
+
+
+@dataclass
+class SearchInputs:
+    """Files and flag values loaded once before a search runs."""
+
+    pins_path: Path
+    pin_rules: PinRules | None = None
+    boosts: list[tuple[str, float]] = field(default_factory=list)
+    redaction_patterns: list[re.Pattern[str]] | None = None
+    saved_locations: list[str] | None = None
+    saved_results: list[SearchResult] | None = None
+
+
+def parse_query(
+    query: str, not_: list[str] | None, normalize: bool, lowercase: bool
+) -> tuple[str, list[str]]:
+    """Split the query into its search text and the terms to exclude.
+
+    Raises:
+        ValueError: If every term of the query is negated
+    """
+    if normalize:
+        query = normalize_query_text(query, lowercase)
+    query, negated_terms = split_negated_terms(query)
+    if not query:
+        raise ValueError("Query must contain at least one term that is not negated")
+    return query, negated_terms + (not_ or [])
+
+
+def clamp_limit(limit: int) -> int:
+    """Cap the limit at MAX_LIMIT, warning on stderr when it is higher.
+
+    Raises:
+        ValueError: If the limit is below 1
+    """
+    from rich.console import Console
+
+    if limit < 1:
+        raise ValueError("Limit must be at least 1")
+    if limit > MAX_LIMIT:
+        Console(stderr=True).print(
+            f"[yellow]Limit {limit} exceeds the maximum of {MAX_LIMIT}, "
+            f"showing at most {MAX_LIMIT} results. "
+            "Narrow the query or path to see other matches.[/yellow]"
+        )
+    return min(limit, MAX_LIMIT)
+
+
+def validate_search_args(
+    limit: int,
+    min_results: int | None = None,
+    limit_per_file: int | None = None,
+    score_precision: int | None = None,
+    depth: int = 1,
+    max_content_lines: int | None = None,
+    before: int = 0,
+    after: int = 0,
+) -> None:
+    """Check the numeric search flags against each other and the limit.
+
+    Raises:
+        ValueError: Naming the first flag that is out of range
+    """
+    if min_results is not None and not 1 <= min_results <= limit:
+        raise ValueError(f"Min results must be between 1 and the limit of {limit}")
+    if limit_per_file is not None and limit_per_file < 1:
+        raise ValueError("Limit per file must be at least 1")
+    if score_precision is not None and score_precision < 0:
+        raise ValueError("Score precision must not be negative")
+    if depth < 1:
+        raise ValueError("Depth must be at least 1")
+    if max_content_lines is not None and max_content_lines < 1:
+        raise ValueError("Max content lines must be at least 1")
+    if before < 0 or after < 0:
+        raise ValueError("Context line counts must not be negative")
+
+
+def resolve_search_root(path: Path, path_as_is: bool = False) -> Path:
+    """Make the search path absolute unless it is used verbatim.
+
+    Raises:
+        ValueError: If the current directory cannot be determined
+    """
+    try:
+        return path if path_as_is else path.expanduser().absolute()
+    except OSError as exc:
+        raise ValueError(
+            "Could not determine current directory. "
+            "Pass the path explicitly: code-context search <query> <path>"
+        ) from exc
+
+
+def load_search_inputs(
+    root: Path,
+    pins_file: Path | None = None,
+    boost_path: list[str] | None = None,
+    redact: bool = False,
+    redact_file: Path | None = None,
+    compare_with: Path | None = None,
+    from_results: Path | None = None,
+) -> SearchInputs:
+    """Read pins, boosts, redaction patterns and saved results for a search.
+
+    Pins default to the pins file under root when it exists.
+
+    Raises:
+        ValueError: If a given file is missing or any input is invalid
+    """
+    if pins_file is not None and not pins_file.is_file():
+        raise ValueError(f"Pins file not found: {pins_file}")
+    inputs = SearchInputs(pins_path=pins_file or root / PINS_FILE_NAME)
+    if inputs.pins_path.is_file():
+        inputs.pin_rules = load_pin_rules(inputs.pins_path)
+    inputs.boosts = [parse_boost(value) for value in boost_path or []]
+    if redact or redact_file is not None:
+        inputs.redaction_patterns = default_redaction_patterns() + (
+            load_redaction_patterns(redact_file) if redact_file is not None else []
+        )
+    if compare_with is not None:
+        inputs.saved_locations = load_saved_locations(compare_with)
+    if from_results is not None:
+        inputs.saved_results = load_saved_results(from_results)
+    return inputs
@@ -0,0 +1,92 @@
+from dataclasses import dataclass, field
+
+from core import SearchResult
+
+from .filters import matches_any_glob
+from .output import OutputType, RenderOptions, print_results, select_results
+
+FILTER_PROMPT = "filter> "
+FILTER_HELP = (
+    "Filter with path:<glob> lang:<language> score:<min> (repeat path or lang "
+    "to allow several), an empty line to show everything, q to quit"
+)
+_QUIT_COMMANDS = {"q", "quit", "exit"}
+
+
+@dataclass
+class FilterExpression:
+    globs: list[str] = field(default_factory=list)
+    languages: list[str] = field(default_factory=list)
+    min_score: float | None = None
+
+
+def parse_filter_expression(text: str) -> FilterExpression:
+    """Parse space separated ``path:``, ``lang:`` and ``score:`` terms.
+
+    Raises:
+        ValueError: If a term has an unknown key or an invalid score
+    """
+    expression = FilterExpression()
+    for term in text.split():
+        key, separator, value = term.partition(":")
+        if not separator or not value:
+            raise ValueError(f"Invalid filter {term!r}, expected <key>:<value>")
+        if key == "path":
+            expression.globs.append(value)
+        elif key == "lang":
+            expression.languages.append(value.lower())
+        elif key == "score":
+            try:
+                expression.min_score = float(value)
+            except ValueError:
+                raise ValueError(f"Invalid minimum score in {term!r}") from None
+        else:
+            raise ValueError(f"Unknown filter {key!r}, use path, lang or score")
+    return expression
+
+
+def matching_indices(
+    results: list[SearchResult], expression: FilterExpression
+) -> list[int]:
+    indices = []
+    for index, result in enumerate(results):
+        if expression.globs and not matches_any_glob(
+            result.relative_path, expression.globs
+        ):
+            continue
+        if expression.languages and result.language.lower() not in expression.languages:
+            continue
+        if expression.min_score is not None and result.score < expression.min_score:
+            continue
+        indices.append(index)
+    return indices
+
+
+def run_interactive_filter(
+    results: list[SearchResult], output_type: OutputType, options: RenderOptions
+) -> None:
+    """Render results, then re-render them for each filter typed at the prompt.
+
+    Filtering only narrows the results already fetched; the server is not
+    queried again. The loop ends on ``q``, end of input or Ctrl-C.
+    """
+    from rich import print
+
+    print_results(results, output_type, options)
+    print(f"[dim]{FILTER_HELP}[/dim]")
+    while True:
+        try:
+            line = input(FILTER_PROMPT).strip()
+        except (EOFError, KeyboardInterrupt):
+            return
+        if line in _QUIT_COMMANDS:
+            return
+        try:
+            expression = parse_filter_expression(line)
+        except ValueError as exc:
+            print(f"[red]{exc}[/red]")
+            continue
+        indices = matching_indices(results, expression)
+        selected, selected_options = select_results(results, options, indices)
+        print_results(selected, output_type, selected_options)
+        print(f"[dim]{len(indices)} of {len(results)} results[/dim]")
@@ -0,0 +1,380 @@
+import re
+from dataclasses import dataclass, replace
+from pathlib import Path
+from typing import Literal
+
+from core import SearchResult
+
+from .context import ResultContext, render_with_context
+from .trim import trim_content
+
+OutputType = Literal["simple", "json", "simple-json", "quickfix", "ctags", "sarif"]
+Annotations = list[dict[str, str | int]]
+HyperlinkScheme = Literal["file", "vscode"]
+
+_SYMBOL_PATTERN = re.compile(
+    r"\b(?:def|class|func|function|fn|struct|interface|trait|enum|type)\s+(\w+)"
+)
+
+_EOL_PATTERN = re.compile(r"\r\n?")
+_IDENTIFIER_CHAR = re.compile(r"\w")
+PREVIEW_WIDTH = 120
+ELLIPSIS = "…"
+_UNSAFE_NAME_PATTERN = re.compile(r"[^A-Za-z0-9._-]")
+_OUTPUT_EXTENSIONS = {
+    "json": ".json",
+    "simple-json": ".json",
+    "ctags": ".tags",
+    "sarif": ".sarif",
+}
+
+SARIF_SCHEMA = "https://json.schemastore.org/sarif-2.1.0.json"
+SARIF_RULE_ID = "code-context/semantic-match"
+
+
+@dataclass
+class RenderOptions:
+    root: Path
+    include_content_hash: bool = False
+    annotations: Annotations | None = None
+    contexts: list[ResultContext | None] | None = None
+    max_content_lines: int | None = None
+    query: str = ""
+    strip_prefix: str | None = None
+    hyperlink_scheme: HyperlinkScheme | None = None
+
+
+def has_mixed_line_endings(content: str) -> bool:
+    crlf = content.count("\r\n")
+    kinds = (crlf, content.count("\n") - crlf, content.count("\r") - crlf)
+    return sum(1 for count in kinds if count) > 1
+
+
+def normalize_line_endings(results: list[SearchResult]) -> list[SearchResult]:
+    return [
+        replace(result, content=_EOL_PATTERN.sub("\n", result.content))
+        for result in results
+    ]
+
+
+def preview(text: str, width: int = PREVIEW_WIDTH) -> str:
+    """Collapse text to one line of at most width characters.
+
+    Cuts before the identifier or word that would be split, falling back to a
+    hard cut when a single token fills the whole width.
+    """
+    line = " ".join(text.split())
+    if len(line) <= width:
+        return line
+
+    cut = width - len(ELLIPSIS)
+    if _IDENTIFIER_CHAR.match(line[cut]) and _IDENTIFIER_CHAR.match(line[cut - 1]):
+        boundary = cut
+        while boundary > 0 and _IDENTIFIER_CHAR.match(line[boundary - 1]):
+            boundary -= 1
+        if boundary > 0:
+            cut = boundary
+    return line[:cut].rstrip() + ELLIPSIS
+
+
+def common_directory(results: list[SearchResult]) -> str:
+    import os.path
+
+    if not results:
+        return ""
+    parents = [os.path.dirname(result.relative_path) for result in results]
+    return "" if "" in parents else os.path.commonpath(parents)
+
+
+def strip_path(relative_path: str, prefix: str | None) -> str:
+    prefix = (prefix or "").strip("/")
+    if prefix and relative_path.startswith(f"{prefix}/"):
+        return f"{ELLIPSIS}/{relative_path[len(prefix) + 1 :]}"
+    return relative_path
+
+
+def round_scores(results: list[SearchResult], precision: int) -> list[SearchResult]:
+    return [replace(result, score=round(result.score, precision)) for result in results]
+
+
+def content_hash(result: SearchResult) -> str:
+    import hashlib
+
+    digest = hashlib.sha256()
+    for part in (result.relative_path, str(result.start_line), result.content):
+        digest.update(part.encode("utf-8"))
+        digest.update(b"\0")
+    return digest.hexdigest()
+
+
+def json_format(results: list[SearchResult], options: RenderOptions) -> str:
+    import json
+    from dataclasses import asdict
+
+    formatted_results = []
+
+    for index, result in enumerate(results):
+        formatted_result = asdict(result)
+        if options.include_content_hash:
+            formatted_result["content_hash"] = content_hash(result)
+        if options.annotations is not None:
+            formatted_result.update(options.annotations[index])
+        context = _context_at(options, index)
+        if context is not None:
+            formatted_result["context_before"] = context.before
+            formatted_result["context_after"] = context.after
+        formatted_results.append(formatted_result)
+
+    return json.dumps(
+        {"results": formatted_results, "total": len(formatted_results)}, indent=2
+    )
+
+
+def json_format_simple(results: list[SearchResult]) -> str:
+    import json
+
+    formatted_results = []
+
+    for result in results:
+        formatted_result = {"content": result.content.strip()}
+        formatted_results.append(formatted_result)
+
+    return json.dumps(formatted_results)
+
+
+def quickfix_format(results: list[SearchResult], root: Path) -> str:
+    lines = []
+    for result in results:
+        text = next(
+            (line.strip() for line in result.content.splitlines() if line.strip()), ""
+        )
+        text = preview(text)
+        lines.append(f"{root / result.relative_path}:{result.start_line}:1: {text}")
+    return "\n".join(lines)
+
+
+def ctags_format(results: list[SearchResult], root: Path) -> str:
+    entries = set()
+    for result in results:
+        match = _SYMBOL_PATTERN.search(result.content)
+        name = match.group(1) if match else Path(result.relative_path).stem
+        entries.add(f'{name}\t{root / result.relative_path}\t{result.start_line};"\tf')
+
+    header = ["!_TAG_FILE_FORMAT\t2\t/extended format/", "!_TAG_FILE_SORTED\t1\t//"]
+    return "\n".join(header + sorted(entries))
+
+
+def sarif_format(results: list[SearchResult], options: RenderOptions) -> str:
+    import json
+
+    message = f"Semantic match for query: {options.query}"
+    sarif_results = [
+        {
+            "ruleId": SARIF_RULE_ID,
+            "level": "note",
+            "message": {"text": message},
+            "locations": [
+                {
+                    "physicalLocation": {
+                        "artifactLocation": {
+                            "uri": result.relative_path,
+                            "uriBaseId": "SRCROOT",
+                        },
+                        "region": {
+                            "startLine": max(result.start_line, 1),
+                            "endLine": max(result.end_line, result.start_line, 1),
+                        },
+                    }
+                }
+            ],
+            "properties": {"score": result.score, "language": result.language},
+        }
+        for result in results
+    ]
+    return json.dumps(
+        {
+            "$schema": SARIF_SCHEMA,
+            "version": "2.1.0",
+            "runs": [
+                {
+                    "tool": {
+                        "driver": {
+                            "name": "code-context",
+                            "rules": [
+                                {
+                                    "id": SARIF_RULE_ID,
+                                    "shortDescription": {
+                                        "text": "Code semantically matching a query"
+                                    },
+                                }
+                            ],
+                        }
+                    },
+                    "originalUriBaseIds": {
+                        "SRCROOT": {"uri": options.root.absolute().as_uri() + "/"}
+                    },
+                    "results": sarif_results,
+                }
+            ],
+        },
+        indent=2,
+    )
+
+
+def plain_format(results: list[SearchResult]) -> str:
+    blocks = [
+        f"Lines {result.start_line}-{result.end_line}\n\n{result.content.strip()}\n"
+        for result in results
+    ]
+    return "\n".join(blocks)
+
+
+def format_results(
+    results: list[SearchResult], output_type: OutputType, options: RenderOptions
+) -> str:
+    if output_type == "json":
+        return json_format(results, options)
+    if output_type == "simple-json":
+        return json_format_simple(results)
+    if output_type == "quickfix":
+        return quickfix_format(results, options.root)
+    if output_type == "ctags":
+        return ctags_format(results, options.root)
+    if output_type == "sarif":
+        return sarif_format(results, options)
+    return plain_format(results)
+
+
+def write_output_dir(
+    results: list[SearchResult],
+    output_type: OutputType,
+    options: RenderOptions,
+    directory: Path,
+) -> int:
+    """Write one output file per matched source file.
+
+    Names are derived from the relative path; colliding names get a numeric
+    suffix in path order so repeated runs produce the same files.
+
+    Returns:
+        Number of files written
+    """
+    grouped: dict[str, list[int]] = {}
+    for index, result in enumerate(results):
+        grouped.setdefault(result.relative_path, []).append(index)
+
+    directory.mkdir(parents=True, exist_ok=True)
+    extension = _OUTPUT_EXTENSIONS.get(output_type, ".txt")
+    used_names: set[str] = set()
+
+    for relative_path in sorted(grouped):
+        indices = grouped[relative_path]
+        stem = _UNSAFE_NAME_PATTERN.sub("_", relative_path.replace("/", "__"))
+        name = f"{stem}{extension}"
+        suffix = 2
+        while name in used_names:
+            name = f"{stem}-{suffix}{extension}"
+            suffix += 1
+        used_names.add(name)
+
+        file_results, file_options = select_results(results, options, indices)
+        text = format_results(file_results, output_type, file_options)
+        (directory / name).write_text(text + "\n", encoding="utf-8")
+
+    return len(used_names)
+
+
+def select_results(
+    results: list[SearchResult], options: RenderOptions, indices: list[int]
+) -> tuple[list[SearchResult], RenderOptions]:
+    """Pick results by index along with their annotations and context."""
+    selected_options = replace(
+        options,
+        annotations=_pick(options.annotations, indices),
+        contexts=_pick(options.contexts, indices),
+    )
+    return [results[i] for i in indices], selected_options
+
+
+def supports_hyperlinks() -> bool:
+    """Whether stdout is a terminal that can be expected to render OSC 8 links."""
+    import os
+    import sys
+
+    return sys.stdout.isatty() and os.environ.get("TERM") != "dumb"
+
+
+def hyperlink_uri(root: Path, result: SearchResult, scheme: HyperlinkScheme) -> str:
+    from urllib.parse import quote
+
+    file_path = (root / result.relative_path).expanduser().resolve()
+    if scheme == "vscode":
+        location = quote(file_path.as_posix().lstrip("/"), safe="/:")
+        return f"vscode://file/{location}:{result.start_line}"
+    return file_path.as_uri()
+
+
+def print_results(
+    results: list[SearchResult], output_type: OutputType, options: RenderOptions
+) -> None:
+    from rich import print, print_json
+
+    if output_type == "json":
+        print_json(json_format(results, options))
+    elif output_type == "simple-json":
+        print_json(json_format_simple(results))
+    elif output_type == "quickfix":
+        _write_plain(quickfix_format(results, options.root))
+    elif output_type == "ctags":
+        _write_plain(ctags_format(results, options.root))
+    elif output_type == "sarif":
+        _write_plain(sarif_format(results, options))
+    else:
+        for index, result in enumerate(results):
+            display_path = strip_path(result.relative_path, options.strip_prefix)
+            if options.hyperlink_scheme is not None:
+                uri = hyperlink_uri(options.root, result, options.hyperlink_scheme)
+                display_path = f"[link={uri}]{display_path}[/link]"
+            print(f"Path: {display_path}")
+            print(f"Start line: {result.start_line}")
+            print(f"End line: {result.end_line}")
+            if options.annotations is not None:
+                for key, value in options.annotations[index].items():
+                    print(f"{key.replace('_', ' ').capitalize()}: {value}")
+            if result.doc is not None:
+                print(f"Explanation: {result.doc}")
+            _print_snippet(result, _context_at(options, index), options)
+
+
+def _print_snippet(
+    result: SearchResult, context: ResultContext | None, options: RenderOptions
+) -> None:
+    from rich import print
+
+    if options.max_content_lines is None:
+        print(render_with_context(result, context))
+        return
+
+    trimmed = trim_content(
+        result.content.strip(), result.language, options.max_content_lines
+    )
+    if trimmed.omitted_lines and context is not None:
+        context = ResultContext(before=context.before, after=[])
+    print(render_with_context(replace(result, content=trimmed.content), context))
+    if trimmed.omitted_lines:
+        print(f"... {trimmed.omitted_lines} more lines")
+
+
+def _pick[T](values: list[T] | None, indices: list[int]) -> list[T] | None:
+    return [values[i] for i in indices] if values is not None else None
+
+
+def _context_at(options: RenderOptions, index: int) -> ResultContext | None:
+    return options.contexts[index] if options.contexts is not None else None
+
+
+def _write_plain(text: str) -> None:
+    import sys
+
+    if text:
+        sys.stdout.write(text + "\n")
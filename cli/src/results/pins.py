@@ -0,0 +1,49 @@
+import json
+from dataclasses import dataclass, field
+from pathlib import Path
+
+from core import SearchResult
+
+from .filters import matches_any_glob
+
+PINS_FILE_NAME = ".code-context-pins.json"
+
+
+@dataclass
+class PinRules:
+    pin: list[str] = field(default_factory=list)
+    exclude: list[str] = field(default_factory=list)
+
+
+def load_pin_rules(path: Path) -> PinRules:
+    """Load pin and exclude glob patterns from a JSON file.
+
+    Raises:
+        ValueError: If the file is not valid JSON or has unexpected fields
+    """
+    try:
+        data = json.loads(path.read_text(encoding="utf-8"))
+    except json.JSONDecodeError as exc:
+        raise ValueError(f"Invalid pins file {path}: {exc}") from exc
+
+    if not isinstance(data, dict):
+        raise ValueError(f"Invalid pins file {path}: expected a JSON object")
+
+    rules = PinRules(pin=data.get("pin", []), exclude=data.get("exclude", []))
+    for patterns in (rules.pin, rules.exclude):
+        if not isinstance(patterns, list) or not all(
+            isinstance(pattern, str) for pattern in patterns
+        ):
+            raise ValueError(f"Invalid pins file {path}: expected lists of globs")
+    return rules
+
+
+def apply_pin_rules(results: list[SearchResult], rules: PinRules) -> list[SearchResult]:
+    kept = [
+        result
+        for result in results
+        if not matches_any_glob(result.relative_path, rules.exclude)
+    ]
+    return sorted(
+        kept, key=lambda result: not matches_any_glob(result.relative_path, rules.pin)
+    )
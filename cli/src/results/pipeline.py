@@ -0,0 +1,83 @@
+from dataclasses import dataclass, field
+from datetime import timedelta
+from pathlib import Path
+
+from core import SearchResult
+
+from .filters import (
+    boost_paths,
+    filter_by_depth,
+    filter_indexed_since,
+    filter_negated_terms,
+    filter_per_file,
+    filter_whole_words,
+)
+from .pins import PinRules, apply_pin_rules
+from .trace import FilterTrace
+from .verify import check_local
+
+
+@dataclass
+class FilterOptions:
+    root: Path
+    query: str
+    negated_terms: list[str] = field(default_factory=list)
+    word: bool = False
+    case_sensitive: bool = False
+    min_depth: int | None = None
+    max_depth: int | None = None
+    since_window: timedelta | None = None
+    boosts: list[tuple[str, float]] = field(default_factory=list)
+    pin_rules: PinRules | None = None
+    drop_stale: bool = False
+    limit_per_file: int | None = None
+
+
+def apply_filters(
+    results: list[SearchResult], options: FilterOptions, trace: FilterTrace
+) -> list[SearchResult]:
+    """Run the client-side filters in order, recording each stage in trace."""
+    trace.reset(len(results))
+    if options.word:
+        results = trace.record(
+            "word",
+            results,
+            filter_whole_words(results, options.query, options.case_sensitive),
+        )
+    if options.negated_terms:
+        results = trace.record(
+            "not",
+            results,
+            filter_negated_terms(
+                results, options.negated_terms, options.word, options.case_sensitive
+            ),
+        )
+    if options.min_depth is not None or options.max_depth is not None:
+        results = trace.record(
+            "depth",
+            results,
+            filter_by_depth(results, options.min_depth, options.max_depth),
+        )
+    if options.since_window is not None:
+        results = trace.record(
+            "since-index", results, filter_indexed_since(results, options.since_window)
+        )
+    if options.boosts:
+        results = trace.record(
+            "boost-path", results, boost_paths(results, options.boosts)
+        )
+    if options.pin_rules is not None:
+        results = trace.record(
+            "pins", results, apply_pin_rules(results, options.pin_rules)
+        )
+    if options.drop_stale:
+        results = trace.record(
+            "drop-stale",
+            results,
+            [r for r in results if check_local(options.root, r) is None],
+        )
+    if options.limit_per_file is not None:
+        results = trace.record(
+            "limit-per-file", results, filter_per_file(results, options.limit_per_file)
+        )
+    return results
@@ -0,0 +1,83 @@
+import re
+from dataclasses import replace
+from pathlib import Path
+
+from core import SearchResult
+
+from .context import ResultContext
+
+REDACTED = "***"
+
+DEFAULT_REDACTION_PATTERNS = [
+    r"AKIA[0-9A-Z]{16}",  # AWS access key id
+    r"gh[pousr]_[A-Za-z0-9]{36,}",  # GitHub tokens
+    r"xox[abprs]-[A-Za-z0-9-]{10,}",  # Slack tokens
+    r"sk-[A-Za-z0-9_-]{20,}",  # OpenAI style secret keys
+    r"eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}",  # JWTs
+    r"-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----",
+    r"(?i)((?:password|passwd|secret|token|api_key|apikey)[\"']?\s*[:=]\s*[\"']?)"
+    r"[^\"'\s]{4,}",  # assignments, the key part is kept
+    r"[\w.+-]+@[\w-]+\.[\w.-]+",  # email addresses
+]
+
+
+def load_redaction_patterns(path: Path) -> list[re.Pattern[str]]:
+    """Read one regular expression per line, skipping blanks and # comments.
+
+    Like the defaults, a pattern's first capture group is kept and only the
+    rest of the match is replaced.
+
+    Raises:
+        ValueError: If the file cannot be read or a pattern does not compile
+    """
+    try:
+        lines = path.read_text(encoding="utf-8").splitlines()
+    except OSError as exc:
+        raise ValueError(f"Could not read redaction file {path}: {exc}") from exc
+
+    patterns = []
+    for number, line in enumerate(lines, 1):
+        if not line.strip() or line.lstrip().startswith("#"):
+            continue
+        try:
+            patterns.append(re.compile(line.strip()))
+        except re.error as exc:
+            raise ValueError(f"{path}:{number}: invalid pattern: {exc}") from exc
+    return patterns
+
+
+def default_redaction_patterns() -> list[re.Pattern[str]]:
+    return [re.compile(pattern) for pattern in DEFAULT_REDACTION_PATTERNS]
+
+
+def redact_text(text: str, patterns: list[re.Pattern[str]]) -> str:
+    for pattern in patterns:
+        if pattern.groups:
+            text = pattern.sub(lambda match: f"{match.group(1) or ''}{REDACTED}", text)
+        else:
+            text = pattern.sub(REDACTED, text)
+    return text
+
+
+def redact_results(
+    results: list[SearchResult], patterns: list[re.Pattern[str]]
+) -> list[SearchResult]:
+    return [
+        replace(
+            result,
+            content=redact_text(result.content, patterns),
+            doc=redact_text(result.doc, patterns) if result.doc is not None else None,
+        )
+        for result in results
+    ]
+
+
+def redact_context(
+    context: ResultContext | None, patterns: list[re.Pattern[str]]
+) -> ResultContext | None:
+    if context is None:
+        return None
+    return ResultContext(
+        before=[redact_text(line, patterns) for line in context.before],
+        after=[redact_text(line, patterns) for line in context.after],
+    )
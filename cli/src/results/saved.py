@@ -0,0 +1,35 @@
+import json
+from dataclasses import fields
+from pathlib import Path
+
+from core import SearchResult
+
+_RESULT_FIELDS = {field.name for field in fields(SearchResult)}
+
+
+def load_saved_results(path: Path) -> list[SearchResult]:
+    """Read full results from a file saved with ``search --output json``.
+
+    Annotations, hashes and context lines added at render time are dropped.
+
+    Raises:
+        ValueError: If the file is not a saved json result set
+    """
+    try:
+        data = json.loads(path.read_text(encoding="utf-8"))
+        return [
+            SearchResult(
+                **{key: value for key, value in item.items() if key in _RESULT_FIELDS}
+            )
+            for item in data["results"]
+        ]
+    except (OSError, ValueError, KeyError, TypeError) as exc:
+        raise ValueError(f"Invalid saved results file {path}: {exc}") from exc
+
+
+def replay_search(
+    results: list[SearchResult], top_k: int, threshold: float = 0.0
+) -> list[SearchResult]:
+    """Answer a search from saved results the way the server would."""
+    matching = [result for result in results if result.score >= threshold]
+    return sorted(matching, key=lambda result: -result.score)[:top_k]
@@ -0,0 +1,81 @@
+from dataclasses import dataclass
+from pathlib import PurePath
+
+from core import SearchResult
+
+
+@dataclass
+class DirectorySummary:
+    directory: str
+    count: int
+    best_score: float
+
+
+def summarize_by_directory(
+    results: list[SearchResult], depth: int = 1
+) -> list[DirectorySummary]:
+    groups: dict[str, DirectorySummary] = {}
+    for result in results:
+        parts = PurePath(result.relative_path).parent.parts[:depth]
+        directory = "/".join(parts) or "."
+        summary = groups.setdefault(directory, DirectorySummary(directory, 0, 0.0))
+        summary.count += 1
+        summary.best_score = max(summary.best_score, result.score)
+
+    return sorted(
+        groups.values(), key=lambda summary: (-summary.count, -summary.best_score)
+    )
+
+
+def print_summary(summaries: list[DirectorySummary], as_json: bool = False) -> None:
+    import json
+    from dataclasses import asdict
+
+    from rich import print, print_json
+    from rich.table import Table
+
+    if as_json:
+        print_json(json.dumps([asdict(summary) for summary in summaries]))
+        return
+
+    table = Table("Directory", "Results", "Best score")
+    for summary in summaries:
+        table.add_row(summary.directory, str(summary.count), f"{summary.best_score:g}")
+    print(table)
+
+
+@dataclass
+class LanguageCount:
+    language: str
+    count: int
+    percentage: float
+
+
+def summarize_by_language(results: list[SearchResult]) -> list[LanguageCount]:
+    from collections import Counter
+
+    counts = Counter(result.language for result in results)
+    total = max(len(results), 1)
+    return [
+        LanguageCount(language, count, round(count / total * 100, 1))
+        for language, count in sorted(
+            counts.items(), key=lambda item: (-item[1], item[0])
+        )
+    ]
+
+
+def print_language_counts(counts: list[LanguageCount], as_json: bool = False) -> None:
+    import json
+    from dataclasses import asdict
+
+    from rich import print, print_json
+    from rich.table import Table
+
+    if as_json:
+        print_json(json.dumps([asdict(count) for count in counts]))
+        return
+
+    table = Table("Language", "Results", "Share")
+    for count in counts:
+        table.add_row(count.language, str(count.count), f"{count.percentage:g}%")
+    print(table)
@@ -0,0 +1,45 @@
+from dataclasses import replace
+from pathlib import Path
+
+from core import SearchResult
+
+
+def expand_to_symbol(root: Path, result: SearchResult) -> SearchResult:
+    """Widen a result to the full declaration enclosing it in the local file.
+
+    Best effort: results whose file is missing, unreadable or unparsable are
+    returned unchanged.
+    """
+    from core.splitters import find_enclosing_declaration
+
+    try:
+        code = (root / result.relative_path).read_text(encoding="utf-8")
+    except (OSError, UnicodeDecodeError):
+        return result
+
+    span = find_enclosing_declaration(
+        code, result.language, result.start_line, result.end_line
+    )
+    if span is None:
+        return result
+
+    start_line = min(span[0], result.start_line)
+    end_line = max(span[1], result.end_line)
+    lines = code.splitlines()[start_line - 1 : end_line]
+    return replace(
+        result, content="\n".join(lines), start_line=start_line, end_line=end_line
+    )
+
+
+def expand_results_to_symbols(
+    root: Path, results: list[SearchResult]
+) -> list[SearchResult]:
+    expanded: list[SearchResult] = []
+    seen: set[tuple[str, int, int]] = set()
+    for result in results:
+        result = expand_to_symbol(root, result)
+        key = (result.relative_path, result.start_line, result.end_line)
+        if key not in seen:
+            seen.add(key)
+            expanded.append(result)
+    return expanded
@@ -0,0 +1,52 @@
+from dataclasses import dataclass, field
+
+from core import SearchResult
+
+
+@dataclass
+class FilterStage:
+    name: str
+    entered: int
+    left: int
+
+
+@dataclass
+class FilterTrace:
+    """How many results entered and left each client-side filter stage."""
+
+    fetched: int = 0
+    stages: list[FilterStage] = field(default_factory=list)
+
+    def reset(self, fetched: int) -> None:
+        self.fetched = fetched
+        self.stages.clear()
+
+    @property
+    def remaining(self) -> int:
+        return self.stages[-1].left if self.stages else self.fetched
+
+    def add(self, name: str, entered: int, left: int) -> None:
+        self.stages.append(FilterStage(name, entered, left))
+
+    def record(
+        self, name: str, before: list[SearchResult], after: list[SearchResult]
+    ) -> list[SearchResult]:
+        self.add(name, len(before), len(after))
+        return after
+
+
+def print_filter_trace(trace: FilterTrace) -> None:
+    from rich.console import Console
+    from rich.table import Table
+
+    table = Table("Stage", "In", "Out", "Dropped", title="Filter funnel")
+    table.add_row("search", "", str(trace.fetched), "")
+    for stage in trace.stages:
+        dropped = stage.entered - stage.left
+        table.add_row(
+            stage.name,
+            str(stage.entered),
+            str(stage.left),
+            f"[red]{dropped}[/red]" if dropped else "0",
+        )
+    Console(stderr=True).print(table)
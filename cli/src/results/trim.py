@@ -0,0 +1,68 @@
+from collections.abc import Callable
+from dataclasses import dataclass
+
+INDENTED_LANGUAGES = {"python", "yaml", "coffeescript", "nim"}
+_STATEMENT_ENDINGS = ("}", ";", ")", "end")
+_CLOSERS = (")", "]", "}")
+
+
+@dataclass
+class TrimmedContent:
+    content: str
+    omitted_lines: int
+
+
+def trim_content(content: str, language: str, max_lines: int) -> TrimmedContent:
+    """Cut content to at most max_lines, preferring statement or block boundaries.
+
+    Indentation-based languages are cut before a line that returns to the
+    snippet's base indentation, other languages after a line that closes a
+    statement or block. Without a boundary in the second half of the allowed
+    lines, content is cut at exactly max_lines.
+    """
+    lines = content.splitlines()
+    if len(lines) <= max_lines:
+        return TrimmedContent(content, 0)
+
+    if language in INDENTED_LANGUAGES:
+        is_boundary = _indentation_boundary(lines)
+    else:
+        is_boundary = _statement_boundary(lines)
+
+    cut = next(
+        (
+            index
+            for index in range(max_lines, max(max_lines // 2, 1) - 1, -1)
+            if is_boundary(index)
+        ),
+        max_lines,
+    )
+    kept = lines[:cut]
+    while len(kept) > 1 and not kept[-1].strip():
+        kept.pop()
+    return TrimmedContent("\n".join(kept), len(lines) - len(kept))
+
+
+def _indentation(line: str) -> int:
+    return len(line) - len(line.lstrip())
+
+
+def _indentation_boundary(lines: list[str]) -> Callable[[int], bool]:
+    non_empty = [line for line in lines if line.strip()]
+    base = min(_indentation(line) for line in non_empty[1:] or non_empty)
+
+    def is_boundary(index: int) -> bool:
+        line = lines[index]
+        if not line.strip():
+            return True
+        return _indentation(line) <= base and not line.lstrip().startswith(_CLOSERS)
+
+    return is_boundary
+
+
+def _statement_boundary(lines: list[str]) -> Callable[[int], bool]:
+    def is_boundary(index: int) -> bool:
+        previous = lines[index - 1].strip()
+        return not previous or previous.endswith(_STATEMENT_ENDINGS)
+
+    return is_boundary
@@ -0,0 +1,27 @@
+from pathlib import Path
+
+from core import SearchResult
+
+STALE_MESSAGE = "index may be stale for this file"
+MISSING_MESSAGE = "file is missing locally"
+
+
+def check_local(root: Path, result: SearchResult) -> str | None:
+    """Compare a result against the current local file lines it points to.
+
+    Chunks are stripped and may start with a partial overlap line, so the
+    comparison ignores whitespace and only requires the indexed content to
+    appear within the local line range.
+
+    Returns:
+        None when the result still matches, otherwise a short reason
+    """
+    try:
+        text = (root / result.relative_path).read_text(encoding="utf-8")
+    except (OSError, UnicodeDecodeError):
+        return MISSING_MESSAGE
+
+    lines = text.splitlines()[result.start_line - 1 : result.end_line]
+    local = " ".join("\n".join(lines).split())
+    indexed = " ".join(result.content.split())
+    return None if indexed in local else STALE_MESSAGE
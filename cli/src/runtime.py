@@ -0,0 +1,142 @@
+import asyncio
+import re
+from collections.abc import AsyncIterator, Coroutine, Iterator
+from contextlib import asynccontextmanager
+from datetime import datetime, timedelta
+from typing import Any
+
+_DURATION_UNITS = {"s": "seconds", "m": "minutes", "h": "hours", "d": "days"}
+_DURATION_PATTERN = re.compile(r"(\d+(?:\.\d+)?)([smhd])")
+_CLOCK_SKEW_MARKERS = ("certificate is not yet valid", "certificate has expired")
+_REFUSED_MARKERS = ("connection refused", "all connection attempts failed")
+_debug_to_stderr = False
+
+
+def parse_duration(value: str) -> timedelta:
+    """Parse durations such as 30s, 10m, 1h30m or 2d.
+
+    Raises:
+        ValueError: If the value is not a valid, positive duration
+    """
+    text = value.strip().lower()
+    matches = list(_DURATION_PATTERN.finditer(text))
+    if not matches or "".join(match.group(0) for match in matches) != text:
+        raise ValueError(f"Invalid duration: {value}")
+    duration = sum(
+        (
+            timedelta(**{_DURATION_UNITS[match.group(2)]: float(match.group(1))})
+            for match in matches
+        ),
+        timedelta(),
+    )
+    if duration <= timedelta():
+        raise ValueError(f"Duration must be positive: {value}")
+    return duration
+
+
+def is_clock_skew_error(exc: BaseException) -> bool:
+    """Detect TLS certificate time-validity failures anywhere in the cause chain.
+
+    Qdrant and httpx wrap the underlying ssl error, so the chain, including
+    qdrant's ``source`` attribute, is walked and messages are matched.
+    """
+    return any(
+        marker in str(current).lower()
+        for current in _exception_chain(exc)
+        for marker in _CLOCK_SKEW_MARKERS
+    )
+
+
+def is_connection_refused(exc: BaseException) -> bool:
+    """Detect a refused connection anywhere in the cause chain."""
+    return any(
+        isinstance(current, ConnectionRefusedError)
+        or any(marker in str(current).lower() for marker in _REFUSED_MARKERS)
+        for current in _exception_chain(exc)
+    )
+
+
+def _exception_chain(exc: BaseException) -> Iterator[BaseException]:
+    seen: set[int] = set()
+    pending: list[BaseException | None] = [exc]
+    while pending:
+        current = pending.pop()
+        if current is None or id(current) in seen:
+            continue
+        seen.add(id(current))
+        yield current
+        source = getattr(current, "source", None)
+        pending.extend(
+            [
+                current.__cause__,
+                current.__context__,
+                source if isinstance(source, BaseException) else None,
+            ]
+        )
+
+
+@asynccontextmanager
+async def run_until(deadline: datetime | None) -> AsyncIterator[None]:
+    """Cancel the wrapped block and exit non-zero once the deadline passes.
+
+    Args:
+        deadline: Absolute wall-clock deadline, naive values are treated as local time
+    """
+    from rich import print
+
+    if deadline is None:
+        yield
+        return
+
+    remaining = deadline.astimezone() - datetime.now().astimezone()
+    timeout = asyncio.timeout(max(remaining.total_seconds(), 0))
+    try:
+        async with timeout:
+            yield
+    except TimeoutError:
+        if not timeout.expired():
+            raise
+        print(f"[red]Deadline {deadline.isoformat()} exceeded[/red]")
+        raise SystemExit(1)
+
+
+async def run_within[T](coroutine: Coroutine[Any, Any, T], limit: timedelta) -> T:
+    """Cancel the command and exit non-zero once it has run longer than limit.
+
+    Cancellation unwinds the command at its next await, so open clients and
+    progress displays are closed before the process exits.
+    """
+    from rich.console import Console
+
+    timeout = asyncio.timeout(limit.total_seconds())
+    try:
+        async with timeout:
+            return await coroutine
+    except TimeoutError:
+        if not timeout.expired():
+            raise
+        Console(stderr=True).print(f"[red]Max runtime {limit} exceeded, aborting[/red]")
+        raise SystemExit(1)
+
+
+def install_debug_toggle() -> None:
+    """Toggle debug logging to stderr each time the process receives SIGUSR1.
+
+    The handler only flips a flag read by the stderr log sink. It is a no-op on
+    platforms without SIGUSR1, such as Windows.
+    """
+    import signal
+
+    if not hasattr(signal, "SIGUSR1"):
+        return
+
+    def toggle(signum: int, frame: object) -> None:
+        global _debug_to_stderr
+        _debug_to_stderr = not _debug_to_stderr
+
+    signal.signal(signal.SIGUSR1, toggle)
+
+
+def debug_toggled(record: object) -> bool:
+    return _debug_to_stderr
+
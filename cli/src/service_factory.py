@@ -1,3 +1,4 @@
+import sys
 from pathlib import Path
 from typing import Literal
 
@@ -10,11 +11,16 @@ from core import (
     SearchService,
     TreeSitterSplitter,
 )
-from core.sync import LocalFileContentReader, SnapshotFileStateRepository
+from core.sync import (
+    LocalFileContentReader,
+    LocalFileLister,
+    SnapshotFileStateRepository,
+)
 from loguru import logger
 from qdrant_client import AsyncQdrantClient
 
 from config import AppSettings
+from runtime import debug_toggled
 
 EmbeddingType = Literal["code", "doc"]
 
@@ -36,6 +42,12 @@ class ServiceFactory:
 
     def initialize_logger(self) -> None:
         logger.remove()
+        logger.add(
+            sys.stderr,
+            level="DEBUG",
+            filter=debug_toggled,
+            format="{time:HH:mm:ss} | {level} | {name}:{function}:{line} | {message}",
+        )
         if self.settings.logging.enabled:
             log_file_path = Path(self.settings.logging.log_file_path).expanduser()
             log_file_path.parent.mkdir(parents=True, exist_ok=True)
@@ -51,11 +63,18 @@ class ServiceFactory:
 
     def get_client(self) -> AsyncQdrantClient:
         if not self._client:
-            self._client = AsyncQdrantClient(
-                url=str(self.settings.qdrant.url),
-                api_key=self.settings.qdrant.api_key,
-                timeout=120,
-            )
+            qdrant = self.settings.qdrant
+            if qdrant.auth_header is None or qdrant.api_key is None:
+                self._client = AsyncQdrantClient(
+                    url=str(qdrant.url), api_key=qdrant.api_key, timeout=120
+                )
+            else:
+                token = " ".join(filter(None, (qdrant.auth_scheme, qdrant.api_key)))
+                self._client = AsyncQdrantClient(
+                    url=str(qdrant.url),
+                    headers={qdrant.auth_header: token},
+                    timeout=120,
+                )
         return self._client
 
     def get_code_embedding_service(self) -> EmbeddingService:
@@ -99,7 +118,8 @@ class ServiceFactory:
             self._synchronizer = FileSynchronizer(
                 file_state_repository=SnapshotFileStateRepository(
                     self.settings.storage.snapshots_dir
-                )
+                ),
+                file_lister=LocalFileLister(self.settings.sync.ignore_file),
             )
         return self._synchronizer
 
@@ -123,6 +143,7 @@ class ServiceFactory:
                 self.get_explainer_service(),
                 self.get_graph_service(),
                 LocalFileContentReader(),
+                self.settings.qdrant.delete_batch_size,
             )
         return self._indexing_service
 
@@ -0,0 +1,56 @@
+import json
+import os
+from pathlib import Path
+
+from core import write_text_atomic
+
+from config import DEFAULT_DIR
+
+WORKSPACES_PATH = DEFAULT_DIR / "workspaces.json"
+WORKSPACE_PREFIX = "@"
+
+
+def load_workspaces() -> dict[str, list[Path]]:
+    """Read named workspaces, each a list of indexed codebase paths.
+
+    Raises:
+        ValueError: If the workspaces file is not valid
+    """
+    if not WORKSPACES_PATH.exists():
+        return {}
+    try:
+        data = json.loads(WORKSPACES_PATH.read_text(encoding="utf-8"))
+        return {
+            name: [Path(member) for member in members]
+            for name, members in data.items()
+        }
+    except (OSError, ValueError, AttributeError, TypeError) as exc:
+        raise ValueError(f"Invalid workspaces file {WORKSPACES_PATH}: {exc}") from exc
+
+
+def workspace_members(path: Path) -> list[Path] | None:
+    """Return the members of an @workspace path, None for any other path.
+
+    Raises:
+        ValueError: If the workspaces file is not valid or has no such workspace
+    """
+    if not str(path).startswith(WORKSPACE_PREFIX):
+        return None
+    members = load_workspaces().get(str(path).removeprefix(WORKSPACE_PREFIX))
+    if not members:
+        raise ValueError(
+            f"Workspace {path} does not exist. Create it with 'workspace add'."
+        )
+    return members
+
+
+def save_workspaces(workspaces: dict[str, list[Path]]) -> None:
+    data = {
+        name: [str(member) for member in members]
+        for name, members in sorted(workspaces.items())
+    }
+    write_text_atomic(WORKSPACES_PATH, json.dumps(data, indent=2))
+
+
+def workspace_root(members: list[Path]) -> Path:
+    return Path(os.path.commonpath([str(member) for member in members]))
@@ -0,0 +1,33 @@
+import json
+from pathlib import Path
+
+import pytest
+
+from results import PinRules, load_search_inputs, validate_search_args
+
+
+@pytest.mark.parametrize("min_results", [0, 6])
+def test_min_results_must_be_within_limit(min_results: int) -> None:
+    with pytest.raises(ValueError, match="between 1 and the limit of 5"):
+        validate_search_args(5, min_results=min_results)
+
+
+def test_min_results_may_equal_limit() -> None:
+    validate_search_args(5, min_results=5)
+
+
+def test_pins_default_to_file_under_root(tmp_path: Path) -> None:
+    pins_path = tmp_path / ".code-context-pins.json"
+    pins_path.write_text(json.dumps({"pin": ["src/**"]}), encoding="utf-8")
+
+    inputs = load_search_inputs(tmp_path, boost_path=["lib/**=0.5"])
+
+    assert inputs.pins_path == pins_path
+    assert inputs.pin_rules == PinRules(pin=["src/**"])
+    assert inputs.boosts == [("lib/**", 0.5)]
+    assert inputs.redaction_patterns is None
+
+
+def test_missing_pins_file_is_rejected(tmp_path: Path) -> None:
+    with pytest.raises(ValueError, match="Pins file not found"):
+        load_search_inputs(tmp_path, pins_file=tmp_path / "pins.json")
@@ -0,0 +1,61 @@
+import json
+from pathlib import Path
+
+from core import SearchResult
+from jsonschema import Draft7Validator
+
+from results import RenderOptions, sarif_format
+
+SCHEMA_PATH = Path(__file__).parent / "schemas" / "sarif-2.1.0-subset.json"
+
+
+def sarif_errors(document: object) -> list[str]:
+    schema = json.loads(SCHEMA_PATH.read_text(encoding="utf-8"))
+    validator = Draft7Validator(schema)
+    return [error.message for error in validator.iter_errors(document)]
+
+
+def search_result(relative_path: str, start_line: int, end_line: int) -> SearchResult:
+    return SearchResult(
+        content="def login(user): ...",
+        doc=None,
+        relative_path=relative_path,
+        start_line=start_line,
+        end_line=end_line,
+        language="python",
+        score=0.8123,
+    )
+
+
+def test_sarif_output_validates_against_schema(tmp_path: Path) -> None:
+    results = [
+        search_result("src/auth.py", 10, 24),
+        search_result("src/session.py", 0, 0),
+    ]
+
+    document = json.loads(sarif_format(results, RenderOptions(tmp_path, query="auth")))
+
+    assert sarif_errors(document) == []
+    regions = [
+        result["locations"][0]["physicalLocation"]["region"]
+        for result in document["runs"][0]["results"]
+    ]
+    assert regions == [
+        {"startLine": 10, "endLine": 24},
+        {"startLine": 1, "endLine": 1},
+    ]
+
+
+def test_empty_sarif_output_validates_against_schema(tmp_path: Path) -> None:
+    document = json.loads(sarif_format([], RenderOptions(tmp_path, query="auth")))
+
+    assert sarif_errors(document) == []
+    assert document["runs"][0]["results"] == []
+
+
+def test_schema_rejects_invalid_sarif(tmp_path: Path) -> None:
+    results = [search_result("src/auth.py", 1, 2)]
+    document = json.loads(sarif_format(results, RenderOptions(tmp_path, query="auth")))
+    document["runs"][0]["results"][0]["level"] = "info"
+
+    assert sarif_errors(document)
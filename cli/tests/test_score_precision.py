@@ -0,0 +1,62 @@
+import re
+from pathlib import Path
+
+import pytest
+from core import SearchResult
+
+from results import (
+    RenderOptions,
+    json_format,
+    print_summary,
+    round_scores,
+    summarize_by_directory,
+)
+
+
+def search_result(relative_path: str, score: float) -> SearchResult:
+    return SearchResult(
+        content="return token",
+        doc=None,
+        relative_path=relative_path,
+        start_line=1,
+        end_line=1,
+        language="python",
+        score=score,
+    )
+
+
+RESULTS = [search_result("src/auth.py", 0.876543), search_result("lib/io.py", 0.25)]
+
+
+@pytest.mark.parametrize(
+    ("precision", "expected"),
+    [(None, [0.876543, 0.25]), (0, [1.0, 0.0]), (2, [0.88, 0.25]), (4, [0.8765, 0.25])],
+)
+def test_json_output_prints_scores_at_precision(
+    precision: int | None, expected: list[float]
+) -> None:
+    results = RESULTS if precision is None else round_scores(RESULTS, precision)
+
+    output = json_format(results, RenderOptions(Path(".")))
+
+    printed = [line.strip() for line in output.splitlines() if '"score"' in line]
+    assert printed == [f'"score": {score},' for score in expected]
+
+
+@pytest.mark.parametrize(
+    ("precision", "expected"),
+    [(None, ["0.876543", "0.25"]), (2, ["0.88", "0.25"]), (3, ["0.877", "0.25"])],
+)
+def test_summary_text_prints_scores_without_trailing_zeros(
+    capsys: pytest.CaptureFixture[str], precision: int | None, expected: list[str]
+) -> None:
+    results = RESULTS if precision is None else round_scores(RESULTS, precision)
+
+    print_summary(summarize_by_directory(results))
+
+    rows = [
+        line
+        for line in capsys.readouterr().out.splitlines()
+        if "src" in line or "lib" in line
+    ]
+    assert [re.findall(r"\d+(?:\.\d+)?", row)[-1] for row in rows] == expected
@@ -0,0 +1,84 @@
+from results import trim_content
+
+
+def test_short_content_is_unchanged() -> None:
+    content = "a = 1\nb = 2"
+
+    trimmed = trim_content(content, "python", 5)
+
+    assert trimmed.content == content
+    assert trimmed.omitted_lines == 0
+
+
+def test_indented_language_cuts_before_line_at_base_indentation() -> None:
+    content = "\n".join(
+        [
+            "    def first(self):",
+            "        x = 1",
+            "        return x",
+            "    def second(self):",
+            "        y = 2",
+            "        z = 3",
+            "        return y + z",
+        ]
+    )
+
+    trimmed = trim_content(content, "python", 5)
+
+    assert trimmed.content == "    def first(self):\n        x = 1\n        return x"
+    assert trimmed.omitted_lines == 4
+
+
+def test_indented_language_does_not_cut_before_closing_bracket() -> None:
+    content = "\n".join(["x = 1", "values = [", "    1,", "]", "y = 2"])
+
+    trimmed = trim_content(content, "python", 3)
+
+    assert trimmed.content == "x = 1"
+    assert trimmed.omitted_lines == 4
+
+
+def test_cuts_at_blank_line_between_statements() -> None:
+    content = "\n".join(
+        [
+            "const a = build(",
+            "  one,",
+            "  two",
+            "",
+            "const b = make(",
+            "  three,",
+            "  four,",
+        ]
+    )
+
+    trimmed = trim_content(content, "javascript", 6)
+
+    assert trimmed.content == "const a = build(\n  one,\n  two"
+    assert trimmed.omitted_lines == 4
+
+
+def test_cuts_after_closed_block() -> None:
+    content = "\n".join(
+        [
+            "function a() {",
+            "  return 1;",
+            "}",
+            "function b() {",
+            "  const x = 1",
+            "  const y = 2",
+        ]
+    )
+
+    trimmed = trim_content(content, "javascript", 5)
+
+    assert trimmed.content == "function a() {\n  return 1;\n}"
+    assert trimmed.omitted_lines == 3
+
+
+def test_falls_back_to_plain_line_cut_without_boundary() -> None:
+    content = "\n".join(["a +"] * 10)
+
+    trimmed = trim_content(content, "javascript", 4)
+
+    assert trimmed.content == "\n".join(["a +"] * 4)
+    assert trimmed.omitted_lines == 6
@@ -1,18 +1,24 @@
 from .services import (
     EmbeddingService,
     ExplainerService,
+    ExtensionStats,
     GraphService,
     IndexingService,
+    IndexingStats,
+    RetryJitter,
     SearchResult,
     SearchService,
+    configure_retry_jitter,
     get_collection_name,
 )
 from .splitters import TreeSitterSplitter
-from .sync import FileSynchronizer
+from .sync import FileSynchronizer, write_text_atomic
 
 __all__ = [
     "GraphService",
     "IndexingService",
+    "IndexingStats",
+    "ExtensionStats",
     "ExplainerService",
     "EmbeddingService",
     "GraphService",
@@ -21,4 +27,7 @@ __all__ = [
     "TreeSitterSplitter",
     "FileSynchronizer",
     "get_collection_name",
+    "write_text_atomic",
+    "RetryJitter",
+    "configure_retry_jitter",
 ]
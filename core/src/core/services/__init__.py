@@ -1,13 +1,24 @@
-from .indexing_service import IndexingService
+from .indexing_service import ExtensionStats, IndexingService, IndexingStats
 from .search_service import SearchResult, SearchService
-from .utils import EmbeddingService, ExplainerService, GraphService, get_collection_name
+from .utils import (
+    EmbeddingService,
+    ExplainerService,
+    GraphService,
+    RetryJitter,
+    configure_retry_jitter,
+    get_collection_name,
+)
 
 __all__ = [
     "IndexingService",
+    "IndexingStats",
+    "ExtensionStats",
     "ExplainerService",
     "EmbeddingService",
     "GraphService",
     "SearchService",
     "SearchResult",
     "get_collection_name",
+    "RetryJitter",
+    "configure_retry_jitter",
 ]
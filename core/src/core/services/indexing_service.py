@@ -1,11 +1,11 @@
 import itertools
-from dataclasses import dataclass
+from dataclasses import dataclass, field
 from datetime import datetime, timezone
 from pathlib import Path
 
 from loguru import logger
 from qdrant_client import AsyncQdrantClient, models
-from qdrant_client.models import FieldCondition, Filter, MatchValue
+from qdrant_client.models import FieldCondition, Filter, MatchAny
 
 from core.splitters import CodeChunk, Splitter
 from core.sync import FileSynchronizer
@@ -28,6 +28,7 @@ from .utils import (
 
 ITER_BATCH_SIZE = 128
 SCROLL_BATCH_SIZE = 512
+DELETE_BATCH_SIZE = 256
 
 
 @dataclass
@@ -36,6 +37,21 @@ class Explanations:
     embeddings: list[Embedding]
 
 
+@dataclass
+class ExtensionStats:
+    files: int = 0
+    chunks: int = 0
+
+
+@dataclass
+class IndexingStats:
+    added: int = 0
+    modified: int = 0
+    removed: int = 0
+    chunks: int = 0
+    by_extension: dict[str, ExtensionStats] = field(default_factory=dict)
+
+
 class IndexingService:
     def __init__(
         self,
@@ -47,6 +63,7 @@ class IndexingService:
         explainer: ExplainerService | None = None,
         graph_service: GraphService | None = None,
         file_content_reader: FileContentReader = LocalFileContentReader(),
+        delete_batch_size: int = DELETE_BATCH_SIZE,
     ):
         self.client = client
         self.synchronizer = file_syncrhonizer
@@ -56,6 +73,7 @@ class IndexingService:
         self.explainer = explainer
         self.graph_service = graph_service
         self.file_content_reader = file_content_reader
+        self.delete_batch_size = delete_batch_size
 
     async def delete(self, codebase_path: Path) -> None:
         codebase_path = codebase_path.expanduser().absolute().resolve()
@@ -78,11 +96,50 @@ class IndexingService:
 
         await self.synchronizer.delete_snapshot(codebase_path)
 
+    async def is_indexed(self, codebase_path: Path) -> bool:
+        codebase_path = codebase_path.expanduser().absolute().resolve()
+
+        return await self.client.collection_exists(get_collection_name(codebase_path))
+
+    async def get_indexed_files(self, codebase_path: Path) -> set[str] | None:
+        """Collect relative paths of all files stored in the index.
+
+        Args:
+            codebase_path: Path to the indexed codebase
+
+        Returns:
+            Set of indexed relative paths, or None if the codebase is not indexed
+        """
+        codebase_path = codebase_path.expanduser().absolute().resolve()
+
+        collection_name = get_collection_name(codebase_path)
+        if not await self.client.collection_exists(collection_name):
+            return None
+
+        paths: set[str] = set()
+        offset: models.ExtendedPointId | None = None
+        while True:
+            points, offset = await self.client.scroll(
+                collection_name,
+                limit=SCROLL_BATCH_SIZE,
+                offset=offset,
+                with_payload=["relative_path"],
+                with_vectors=False,
+            )
+            for point in points:
+                relative_path = (point.payload or {}).get("relative_path")
+                if relative_path:
+                    paths.add(str(relative_path))
+            if offset is None:
+                break
+
+        return paths
+
     async def index(
         self,
         codebase_path: Path,
         force_reindex: bool = False,
-    ) -> None:
+    ) -> IndexingStats:
         """Index a codebase, automatically handling initial indexing or incremental reindexing.
 
         Args:
@@ -112,10 +169,22 @@ class IndexingService:
 
         if results.num_changes == 0:
             logger.debug("No changes found")
-            return
+            return IndexingStats()
 
         await self._delete_file_chunks(collection_name, results.to_remove)
         chunks = await self._get_chunks(codebase_path, results.to_add, self.splitter)
+        stats = IndexingStats(
+            added=len(results.added),
+            modified=len(results.modified),
+            removed=len(results.removed),
+            chunks=len(chunks),
+        )
+        for file in results.to_add:
+            stats.by_extension.setdefault(_extension(file), ExtensionStats()).files += 1
+        for chunk in chunks:
+            extension = _extension(chunk.file_path)
+            stats.by_extension.setdefault(extension, ExtensionStats()).chunks += 1
+
         for chunk_batch in itertools.batched(chunks, ITER_BATCH_SIZE):
             batch_list = list(chunk_batch)
             if not batch_list:
@@ -135,6 +204,8 @@ class IndexingService:
 
             await self.client.upsert(collection_name, points)
 
+        return stats
+
     async def _augment_with_explanations(
         self, chunks: list[CodeChunk]
     ) -> list[CodeChunk]:
@@ -291,9 +362,33 @@ class IndexingService:
     async def _delete_file_chunks(
         self, collection_name: str, file_paths: list[str]
     ) -> None:
-        for path in file_paths:
+        failed: list[str] = []
+        for path_batch in itertools.batched(file_paths, self.delete_batch_size):
             filter_condition = Filter(
-                must=[FieldCondition(key="relative_path", match=MatchValue(value=path))]
+                must=[
+                    FieldCondition(
+                        key="relative_path", match=MatchAny(any=list(path_batch))
+                    )
+                ]
             )
+            try:
+                await self.client.delete(collection_name, filter_condition)
+            except Exception as exc:
+                logger.warning(
+                    "Failed to delete chunks for a batch of {} files: {}",
+                    len(path_batch),
+                    exc,
+                )
+                failed.extend(path_batch)
+
+        logger.debug(
+            "Deleted chunks for {} files ({} failed)",
+            len(file_paths) - len(failed),
+            len(failed),
+        )
+        if failed:
+            raise RuntimeError(f"Failed to delete chunks for {len(failed)} files")
+
 
-            await self.client.delete(collection_name, filter_condition)
+def _extension(path: str | Path) -> str:
+    return Path(path).suffix or "(none)"
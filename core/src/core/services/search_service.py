@@ -1,5 +1,7 @@
+from collections.abc import AsyncIterator
 from dataclasses import dataclass
 from pathlib import Path
+from typing import Any
 
 from loguru import logger
 from qdrant_client import AsyncQdrantClient, models
@@ -23,11 +25,14 @@ class SearchResult:
     end_line: int
     language: str
     score: float
+    indexed_at: str | None = None
 
 
 class SearchService:
 
+    EXPORT_QUERY_LIMIT = 1000
     _DEFAULT_GRAPH_LIMIT = 30
+    _EXPORT_PAGE_SIZE = 256
 
     def __init__(
         self,
@@ -88,23 +93,25 @@ class SearchService:
         results = []
         point_ids: list[str] = []
         for point in search_result.points:
-            payload = point.payload or {}
             point_ids.append(str(point.id))
-            results.append(
-                SearchResult(
-                    content=payload.get("content", ""),
-                    doc=payload.get("doc", None),
-                    relative_path=payload.get("relative_path", ""),
-                    start_line=payload.get("start_line", 0),
-                    end_line=payload.get("end_line", 0),
-                    language=payload.get("language", "unknown"),
-                    score=point.score,
-                )
-            )
+            results.append(self._to_result(point.payload or {}, point.score))
 
         logger.debug("Found {} results for text query", len(results))
         return results, point_ids
 
+    @staticmethod
+    def _to_result(payload: dict[str, Any], score: float) -> SearchResult:
+        return SearchResult(
+            content=payload.get("content", ""),
+            doc=payload.get("doc", None),
+            relative_path=payload.get("relative_path", ""),
+            start_line=payload.get("start_line", 0),
+            end_line=payload.get("end_line", 0),
+            language=payload.get("language", "unknown"),
+            score=score,
+            indexed_at=payload.get("indexed_at", None),
+        )
+
     async def search(
         self,
         codebase_path: Path,
@@ -113,6 +120,7 @@ class SearchService:
         threshold: float = 0.5,
         max_graph_hops: int | None = None,
         graph_limit: int | None = None,
+        resolve_path: bool = True,
     ) -> list[SearchResult]:
         """Search indexed code semantically.
 
@@ -124,6 +132,7 @@ class SearchService:
             threshold: Similarity threshold (0.0-1.0)
             max_graph_hops: Optional graph expansion depth (>=1) to augment results
             graph_limit: Optional limit for number of graph nodes (defaults to 30)
+            resolve_path: Resolve the path locally, disable for paths on another host
 
         Returns:
             List of search results
@@ -144,7 +153,8 @@ class SearchService:
         if max_graph_hops is not None and max_graph_hops < 1:
             raise ValueError("max_graph_hops must be >= 1 when provided")
 
-        codebase_path = codebase_path.expanduser().absolute().resolve()
+        if resolve_path:
+            codebase_path = codebase_path.expanduser().absolute().resolve()
 
         collection_name = get_collection_name(codebase_path)
 
@@ -176,6 +186,66 @@ class SearchService:
         logger.debug("Found {} relevant results", len(final_results))
         return final_results
 
+    async def export(
+        self,
+        codebase_path: Path,
+        query: str | None = None,
+        threshold: float = 0.0,
+        max_results: int | None = None,
+    ) -> AsyncIterator[list[SearchResult]]:
+        """Yield every chunk of a codebase, or the matches of a query, in pages.
+
+        Without a query the collection is scrolled in storage order and results
+        carry a score of 0. With a query a single fused search for max_results
+        (default 1000) is run and split into pages; fused rankings are not
+        stable under offset paging, so matches beyond that limit are not exported.
+
+        Args:
+            codebase_path: Path to the indexed codebase
+            query: Optional search query, all chunks are exported when omitted
+            threshold: Similarity threshold (0.0-1.0) for query results
+            max_results: Stop after this many results
+
+        Raises:
+            RuntimeError: If the codebase is not indexed
+        """
+        codebase_path = codebase_path.expanduser().absolute().resolve()
+        collection_name = get_collection_name(codebase_path)
+        if not await self.client.collection_exists(collection_name):
+            raise RuntimeError(
+                f"Collection not indexed {collection_name}, path: {codebase_path}"
+            )
+
+        if query:
+            results, _ = await self._perform_search(
+                collection_name,
+                query,
+                max_results or self.EXPORT_QUERY_LIMIT,
+                threshold,
+            )
+            for start in range(0, len(results), self._EXPORT_PAGE_SIZE):
+                yield results[start : start + self._EXPORT_PAGE_SIZE]
+            return
+
+        exported = 0
+        offset: models.ExtendedPointId | None = None
+        while max_results is None or exported < max_results:
+            page_size = self._EXPORT_PAGE_SIZE
+            if max_results is not None:
+                page_size = min(page_size, max_results - exported)
+            points, offset = await self.client.scroll(
+                collection_name,
+                limit=page_size,
+                offset=offset,
+                with_payload=True,
+                with_vectors=False,
+            )
+            if points:
+                exported += len(points)
+                yield [self._to_result(point.payload or {}, 0.0) for point in points]
+            if offset is None:
+                break
+
     async def _expand_with_graph(
         self,
         collection_name: str,
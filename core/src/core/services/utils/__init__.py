@@ -2,6 +2,7 @@ from .collection_name import get_collection_name
 from .embedding_service import Embedding, EmbeddingService
 from .explainer_service import ExplainerService
 from .graph_service import GraphService, GraphNode
+from .retry import RetryJitter, configure_retry_jitter
 
 __all__ = [
     "EmbeddingService",
@@ -10,4 +11,6 @@ __all__ = [
     "GraphService",
     "GraphNode",
     "get_collection_name",
+    "RetryJitter",
+    "configure_retry_jitter",
 ]
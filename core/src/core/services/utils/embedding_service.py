@@ -6,9 +6,10 @@ from tenacity import (
     retry,
     retry_if_exception_type,
     stop_after_attempt,
-    wait_exponential,
 )
 
+from .retry import RATE_LIMIT_WAIT
+
 Embedding = list[float]
 
 
@@ -27,7 +28,7 @@ class EmbeddingService:
         return self._size
 
     @retry(
-        wait=wait_exponential(min=5, max=20),
+        wait=RATE_LIMIT_WAIT,
         stop=stop_after_attempt(3),
         retry=retry_if_exception_type(RateLimitError),
         before_sleep=lambda x: logger.warning("Rate limit hit {} {}", x.fn, x.args),
@@ -38,7 +39,7 @@ class EmbeddingService:
         return response.data[0].embedding
 
     @retry(
-        wait=wait_exponential(min=5, max=20),
+        wait=RATE_LIMIT_WAIT,
         stop=stop_after_attempt(3),
         retry=retry_if_exception_type(RateLimitError),
         before_sleep=lambda x: logger.warning("Rate limit hit {} {}", x.fn, x.args),
@@ -0,0 +1,45 @@
+import random
+from typing import Literal
+
+from tenacity import RetryCallState, wait_exponential
+from tenacity.wait import wait_base
+
+RetryJitter = Literal["full", "none"]
+
+
+class RateLimitWait(wait_base):
+    """Exponential backoff between rate limited requests.
+
+    With full jitter each wait is drawn uniformly between zero and the
+    exponential delay, so many clients limited at the same moment do not retry
+    in lockstep. Pass a seeded ``rng`` to make the sequence reproducible.
+    """
+
+    def __init__(
+        self,
+        min: float = 5,
+        max: float = 20,
+        jitter: RetryJitter = "full",
+        rng: random.Random | None = None,
+    ) -> None:
+        self._exponential = wait_exponential(min=min, max=max)
+        self.jitter = jitter
+        self.rng = rng or random.Random()
+
+    def __call__(self, retry_state: RetryCallState) -> float:
+        delay = self._exponential(retry_state)
+        if self.jitter == "none":
+            return delay
+        return self.rng.uniform(0, delay)
+
+
+RATE_LIMIT_WAIT = RateLimitWait()
+
+
+def configure_retry_jitter(
+    jitter: RetryJitter, rng: random.Random | None = None
+) -> None:
+    """Set the jitter used by every rate limit retry in this process."""
+    RATE_LIMIT_WAIT.jitter = jitter
+    if rng is not None:
+        RATE_LIMIT_WAIT.rng = rng
@@ -1,4 +1,5 @@
 from .base import Splitter
+from .symbols import find_enclosing_declaration
 from .tree_sitter import TreeSitterSplitter
 from .types import CodeChunk
 from .utils import SUPPORTED_EXTENSIONS, is_file_supported
@@ -9,4 +10,5 @@ __all__ = [
     "CodeChunk",
     "SUPPORTED_EXTENSIONS",
     "is_file_supported",
+    "find_enclosing_declaration",
 ]
@@ -0,0 +1,61 @@
+from typing import cast
+
+from tree_sitter import Node
+from tree_sitter_language_pack import SupportedLanguage, get_parser
+
+from .utils import SPLITTABLE_NODE_TYPES
+
+
+def find_enclosing_declaration(
+    code: str, language: str, start_line: int, end_line: int
+) -> tuple[int, int] | None:
+    """Find the 1-based line range of the declaration enclosing a line range.
+
+    Languages with splittable node types are parsed with Tree-Sitter; others
+    fall back to matching the nearest unclosed brace around the range.
+
+    Returns:
+        Start and end line of the innermost enclosing declaration, or None
+    """
+    lines = code.splitlines()
+    lang = cast(SupportedLanguage, language)
+    node_types = SPLITTABLE_NODE_TYPES.get(lang)
+    if node_types is None:
+        return _enclosing_braces(lines, start_line, end_line)
+
+    try:
+        tree = get_parser(lang).parse(code.encode("utf-8"))
+    except Exception:
+        return None
+
+    last_row = min(max(end_line, start_line), len(lines)) - 1
+    node: Node | None = tree.root_node.descendant_for_point_range(
+        (start_line - 1, 0), (last_row, len(lines[last_row]) if lines else 0)
+    )
+    while node is not None:
+        if node.type in node_types:
+            return node.start_point[0] + 1, node.end_point[0] + 1
+        node = node.parent
+    return None
+
+
+def _enclosing_braces(
+    lines: list[str], start_line: int, end_line: int
+) -> tuple[int, int] | None:
+    depth = 0
+    opening = None
+    for index in range(min(start_line, len(lines)) - 1, -1, -1):
+        line = lines[index]
+        depth += line.count("}") - line.count("{")
+        if depth < 0:
+            opening = index
+            break
+    if opening is None:
+        return None
+
+    depth = 0
+    for index in range(opening, len(lines)):
+        depth += lines[index].count("{") - lines[index].count("}")
+        if depth <= 0 and index + 1 >= end_line:
+            return opening + 1, index + 1
+    return None
@@ -1,3 +1,4 @@
+from .atomic import write_text_atomic
 from .content_readers import LocalFileContentReader
 from .file_listing import LocalFileLister
 from .files import FileSynchronizer
@@ -8,4 +9,5 @@ __all__ = [
     "LocalFileLister",
     "LocalFileContentReader",
     "SnapshotFileStateRepository",
+    "write_text_atomic",
 ]
@@ -1,4 +1,4 @@
-from .local import LocalFileLister
+from .local import CODE_CONTEXT_IGNORE_FILE, LocalFileLister
 from .protocol import FileLister
 
-__all__ = ["FileLister", "LocalFileLister"]
+__all__ = ["CODE_CONTEXT_IGNORE_FILE", "FileLister", "LocalFileLister"]
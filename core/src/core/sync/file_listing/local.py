@@ -6,9 +6,15 @@ from core.splitters import is_file_supported
 
 from .protocol import FileLister
 
+GITIGNORE_FILE = ".gitignore"
+CODE_CONTEXT_IGNORE_FILE = ".code-context-ignore"
+
 
 class LocalFileLister(FileLister):
 
+    def __init__(self, ignore_file_name: str = CODE_CONTEXT_IGNORE_FILE) -> None:
+        self.ignore_file_names = (GITIGNORE_FILE, ignore_file_name)
+
     async def list_metadata(
         self, root: Path, ignore_patterns: list[str] | frozenset[str] | None
     ) -> dict[str, tuple[int, float, int | None]]:
@@ -23,7 +29,9 @@ class LocalFileLister(FileLister):
 
         while stack:
             directory = stack.pop()
-            _record_gitignore_patterns(directory, root, gitignore_map)
+            _record_gitignore_patterns(
+                directory, root, gitignore_map, self.ignore_file_names
+            )
             _collect_entries(
                 directory,
                 root,
@@ -40,16 +48,22 @@ def _record_gitignore_patterns(
     directory: Path,
     root: Path,
     gitignore_map: dict[str, list[tuple[str, bool]]],
+    ignore_file_names: tuple[str, ...],
 ) -> None:
-    gitignore_file = directory / ".gitignore"
-    if not gitignore_file.is_file():
+    # Later files take precedence since the last matching pattern wins
+    patterns: list[tuple[str, bool]] = []
+    for file_name in ignore_file_names:
+        ignore_file = directory / file_name
+        if ignore_file.is_file():
+            patterns.extend(_parse_gitignore_file(ignore_file, root))
+    if not patterns:
         return
     key = (
         ""
         if directory.resolve() == root.resolve()
         else str(directory.relative_to(root)).replace(os.sep, "/").strip("/")
     )
-    gitignore_map[key] = _parse_gitignore_file(gitignore_file, root)
+    gitignore_map[key] = patterns
 
 
 def _collect_entries(
@@ -28,11 +28,15 @@ class FileSynchronizer:
             (ignore_patterns or []) + DEFAULT_IGNORE_PATTERNS
         )
 
+    async def list_files(
+        self, codebase_path: Path
+    ) -> dict[str, tuple[int, float, int | None]]:
+        codebase_path = codebase_path.expanduser().resolve()
+        return await self.file_lister.list_metadata(codebase_path, self.ignore_patterns)
+
     async def check_for_changes(self, codebase_path: Path) -> DetectedChanges:
         codebase_path = codebase_path.expanduser().resolve()
-        current_meta = await self.file_lister.list_metadata(
-            codebase_path, self.ignore_patterns
-        )
+        current_meta = await self.list_files(codebase_path)
 
         if not self.state_repository.has_state(codebase_path):
             initial_records = self._build_snapshot_records(
@@ -55,6 +59,21 @@ class FileSynchronizer:
 
         return changes
 
+    async def peek_changes(self, codebase_path: Path) -> DetectedChanges:
+        """Detect changes since the last snapshot without updating it."""
+        codebase_path = codebase_path.expanduser().resolve()
+        current_meta = await self.list_files(codebase_path)
+
+        if not self.state_repository.has_state(codebase_path):
+            return DetectedChanges(added=sorted(current_meta.keys()))
+
+        return await compare_snapshot_to_current(
+            codebase_path,
+            self.state_repository.load(codebase_path),
+            current_meta,
+            self.content_reader,
+        )
+
     async def delete_snapshot(self, codebase_path: Path) -> None:
         """
         Delete the snapshot file for the given codebase path (if present).
@@ -3,6 +3,7 @@ from pathlib import Path
 
 import xxhash
 
+from ..atomic import write_text_atomic
 from .repository import FileRecord, FileStateRepository
 
 SNAPSHOT_VERSION = 1
@@ -40,7 +41,7 @@ class SnapshotFileStateRepository(FileStateRepository):
             "files": {path: record.to_dict() for path, record in files.items()},
         }
         snapshot_path = self._snapshot_path_for(codebase_path)
-        snapshot_path.write_text(json.dumps(payload, indent=2), encoding="utf-8")
+        write_text_atomic(snapshot_path, json.dumps(payload, indent=2))
 
     def delete(self, codebase_path: Path) -> None:
         path = self._snapshot_path_for(codebase_path)
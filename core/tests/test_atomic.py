@@ -0,0 +1,50 @@
+import json
+import threading
+from concurrent.futures import ThreadPoolExecutor
+from pathlib import Path
+
+import pytest
+
+from core import write_text_atomic
+
+WRITERS = 8
+WRITES_PER_WRITER = 25
+
+
+def test_concurrent_writers_always_leave_a_complete_file(tmp_path: Path) -> None:
+    target = tmp_path / "state.json"
+    write_text_atomic(target, json.dumps({"writer": None, "items": []}))
+    stop = threading.Event()
+    reads: list[dict[str, object]] = []
+
+    def write(writer: int) -> None:
+        for index in range(WRITES_PER_WRITER):
+            payload = {"writer": writer, "items": [index] * 2000}
+            write_text_atomic(target, json.dumps(payload))
+
+    def read() -> None:
+        while not stop.is_set():
+            reads.append(json.loads(target.read_text(encoding="utf-8")))
+
+    with ThreadPoolExecutor(WRITERS + 1) as pool:
+        reader = pool.submit(read)
+        writers = [pool.submit(write, writer) for writer in range(WRITERS)]
+        for future in writers:
+            future.result()
+        stop.set()
+        reader.result()
+
+    assert reads
+    assert json.loads(target.read_text(encoding="utf-8"))["writer"] in range(WRITERS)
+    assert [path.name for path in tmp_path.iterdir()] == ["state.json"]
+
+
+def test_failed_write_removes_temporary_file(tmp_path: Path) -> None:
+    target = tmp_path / "state.json"
+    target.write_text("{}", encoding="utf-8")
+
+    with pytest.raises(UnicodeEncodeError):
+        write_text_atomic(target, "\udcff")
+
+    assert target.read_text(encoding="utf-8") == "{}"
+    assert [path.name for path in tmp_path.iterdir()] == ["state.json"]
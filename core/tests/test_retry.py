@@ -0,0 +1,43 @@
+import random
+
+from tenacity import RetryCallState
+
+from core.services.utils.retry import RateLimitWait
+
+SEED = 1234
+EXPONENTIAL_DELAYS = [5, 5, 5, 8, 16, 20, 20, 20]
+
+
+def retry_state(attempt_number: int) -> RetryCallState:
+    state = RetryCallState(retry_object=None, fn=None, args=(), kwargs={})
+    state.attempt_number = attempt_number
+    return state
+
+
+def waits(wait: RateLimitWait, attempts: int) -> list[float]:
+    return [wait(retry_state(attempt)) for attempt in range(1, attempts + 1)]
+
+
+def test_no_jitter_follows_capped_exponential_backoff() -> None:
+    wait = RateLimitWait(jitter="none")
+
+    assert waits(wait, len(EXPONENTIAL_DELAYS)) == EXPONENTIAL_DELAYS
+
+
+def test_full_jitter_is_reproducible_with_seeded_rng() -> None:
+    expected_rng = random.Random(SEED)
+    expected = [expected_rng.uniform(0, delay) for delay in EXPONENTIAL_DELAYS]
+
+    first = waits(RateLimitWait(rng=random.Random(SEED)), len(EXPONENTIAL_DELAYS))
+    second = waits(RateLimitWait(rng=random.Random(SEED)), len(EXPONENTIAL_DELAYS))
+
+    assert first == expected
+    assert second == expected
+
+
+def test_full_jitter_stays_within_exponential_delay_and_cap() -> None:
+    wait = RateLimitWait(min=1, max=8, rng=random.Random(SEED))
+
+    for attempt in range(1, 50):
+        delay = min(max(2 ** (attempt - 1), 1), 8)
+        assert 0 <= wait(retry_state(attempt)) <= delay